@@ -2,7 +2,10 @@ module github.com/moshfiq123456/mt-toon
 
 go 1.25.4
 
-require github.com/stretchr/testify v1.11.1
+require (
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/time v0.12.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
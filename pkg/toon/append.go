@@ -0,0 +1,49 @@
+package toon
+
+import "bytes"
+
+// AppendDataTo appends the raw data bytes to dst and returns the extended slice
+// Nothing is appended when there is no data, so callers aggregating several
+// responses can grow one buffer without intermediate copies
+func (h *Handler) AppendDataTo(dst []byte) []byte {
+	if h == nil {
+		return dst
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil {
+		return dst
+	}
+	return append(dst, h.resp.Data...)
+}
+
+// AppendDataToBuffer writes the raw data bytes to buf
+// Returns the number of bytes written, which is zero when there is no data
+func (h *Handler) AppendDataToBuffer(buf *bytes.Buffer) int {
+	if buf == nil {
+		return 0
+	}
+
+	before := buf.Len()
+	h.copyDataTo(buf)
+	return buf.Len() - before
+}
+
+// copyDataTo writes the raw data to buf under the read lock
+// It returns false if there is no data
+func (h *Handler) copyDataTo(buf *bytes.Buffer) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil || len(h.resp.Data) == 0 {
+		return false
+	}
+	buf.Write(h.resp.Data)
+	return true
+}
@@ -0,0 +1,70 @@
+package toon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func aggregateHandlers(t *testing.T) []*Handler {
+	t.Helper()
+
+	bodies := []string{
+		`{"success": true, "data": {"id": 1}}`,
+		`{"success": true, "data": {"id": 2}}`,
+		`{"success": true, "data": {"id": 3}}`,
+	}
+
+	handlers := make([]*Handler, 0, len(bodies))
+	for _, body := range bodies {
+		h, err := NewHandler([]byte(body))
+		require.NoError(t, err)
+		handlers = append(handlers, h)
+	}
+	return handlers
+}
+
+func TestAppendDataToBuildsArray(t *testing.T) {
+	out := []byte{'['}
+	for i, h := range aggregateHandlers(t) {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		out = h.AppendDataTo(out)
+	}
+	out = append(out, ']')
+
+	assert.JSONEq(t, `[{"id": 1}, {"id": 2}, {"id": 3}]`, string(out))
+}
+
+func TestAppendDataToBufferBuildsArray(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, h := range aggregateHandlers(t) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		assert.Equal(t, len(`{"id": 1}`), h.AppendDataToBuffer(&buf))
+	}
+	buf.WriteByte(']')
+
+	assert.JSONEq(t, `[{"id": 1}, {"id": 2}, {"id": 3}]`, buf.String())
+}
+
+func TestAppendDataToEmpty(t *testing.T) {
+	h, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	dst := []byte("prefix")
+	assert.Equal(t, "prefix", string(h.AppendDataTo(dst)))
+
+	var buf bytes.Buffer
+	assert.Equal(t, 0, h.AppendDataToBuffer(&buf))
+	assert.Equal(t, 0, h.AppendDataToBuffer(nil))
+
+	var nilHandler *Handler
+	assert.Equal(t, "prefix", string(nilHandler.AppendDataTo(dst)))
+	assert.Equal(t, 0, nilHandler.AppendDataToBuffer(&buf))
+}
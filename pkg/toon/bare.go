@@ -0,0 +1,97 @@
+package toon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FromHTTPResponseBare creates a Handler from a response without a Toon
+// envelope, for minimalist APIs that signal success only by status code
+// A 2xx status is a success whose data is the whole body, which must be
+// JSON; an empty body leaves the data empty
+// Any other status is a failure whose error code is derived from the status
+// text (e.g. NOT_FOUND for 404, or HTTP_599 without one) and whose Details
+// hold the body
+// Headers and trailers are captured as in FromHTTPResponse; RawBody returns
+// the bare body
+func FromHTTPResponseBare(httpResp *http.Response) (*Handler, error) {
+	handler, err := readBareHTTPResponse(httpResp)
+	if err != nil && httpResp != nil {
+		return nil, withContext(err, requestContext(httpResp.Request))
+	}
+	return handler, err
+}
+
+// readBareHTTPResponse reads the body and builds the handler for FromHTTPResponseBare
+func readBareHTTPResponse(httpResp *http.Response) (*Handler, error) {
+	body, err := readHTTPBody(context.Background(), httpResp)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := newBareHandler(body, httpResp.StatusCode)
+	if err != nil {
+		return nil, withContext(err, map[string]interface{}{
+			"status_code":  httpResp.StatusCode,
+			"content_type": httpResp.Header.Get("Content-Type"),
+		})
+	}
+
+	handler.captureHTTPResponse(httpResp)
+	return handler, nil
+}
+
+// newBareHandler builds a handler from a bare body and its status code
+func newBareHandler(body []byte, statusCode int) (*Handler, error) {
+	trimmed := bytes.TrimSpace(stripBOM(body))
+
+	resp := &Response{Success: statusCode >= 200 && statusCode < 300}
+	if resp.Success {
+		if len(trimmed) > 0 {
+			if !json.Valid(trimmed) {
+				return nil, &ValidationError{
+					Code:    ErrCodeJSONUnmarshal,
+					Message: "response body is not valid JSON",
+					Context: map[string]interface{}{
+						"body_size": len(body),
+					},
+				}
+			}
+			resp.Data = json.RawMessage(trimmed)
+		}
+	} else {
+		resp.Error = &ResponseError{
+			Code:    statusErrorCode(statusCode),
+			Message: http.StatusText(statusCode),
+			Details: string(trimmed),
+		}
+		if resp.Error.Message == "" {
+			resp.Error.Message = "HTTP status " + strconv.Itoa(statusCode)
+		}
+	}
+
+	return &Handler{
+		resp: resp,
+		body: body,
+		responseState: responseState{
+			statusCode: statusCode,
+			bare:       true,
+			parsedAt:   Now(),
+		},
+	}, nil
+}
+
+// statusErrorCode derives an error code from the status text, e.g.
+// "Not Found" becomes NOT_FOUND; statuses without text become HTTP_<code>
+func statusErrorCode(statusCode int) string {
+	text := http.StatusText(statusCode)
+	if text == "" {
+		return "HTTP_" + strconv.Itoa(statusCode)
+	}
+
+	return strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(strings.ToUpper(text))
+}
@@ -0,0 +1,142 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHTTPResponseBareSuccess(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{
+		"Content-Type": {"application/json"},
+		"Retry-After":  {"3"},
+	}, `[{"id": 1}, {"id": 2}]`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseBare(resp)
+	require.NoError(t, err)
+
+	assert.True(t, handler.IsSuccess())
+	assert.Nil(t, handler.GetError())
+	assert.JSONEq(t, `[{"id": 1}, {"id": 2}]`, string(handler.GetData()))
+	assert.Equal(t, http.StatusOK, handler.StatusCode())
+	assert.Equal(t, OutcomeSuccess, handler.Outcome())
+
+	var items []struct{ ID int }
+	require.NoError(t, handler.UnmarshalData(&items))
+	assert.Len(t, items, 2)
+
+	wait, ok := handler.RetryAfterHeader()
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+}
+
+func TestFromHTTPResponseBareNotFound(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusNotFound, nil, `{"detail": "no such user"}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseBare(resp)
+	require.NoError(t, err)
+
+	assert.False(t, handler.IsSuccess())
+	require.NotNil(t, handler.GetError())
+	assert.Equal(t, "NOT_FOUND", handler.GetError().Code)
+	assert.Equal(t, "Not Found", handler.GetError().Message)
+	assert.Equal(t, `{"detail": "no such user"}`, handler.GetError().Details)
+	assert.Equal(t, http.StatusNotFound, handler.StatusCode())
+	assert.Equal(t, http.StatusNotFound, handler.HTTPStatus())
+	assert.Equal(t, OutcomeClientError, handler.Outcome())
+}
+
+func TestFromHTTPResponseBareNoContent(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusNoContent, nil, "")
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseBare(resp)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.True(t, handler.IsEmpty())
+}
+
+func TestFromHTTPResponseBareServerError(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusInternalServerError, nil, "upstream exploded")
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseBare(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "INTERNAL_SERVER_ERROR", handler.GetError().Code)
+	assert.Equal(t, "upstream exploded", handler.GetError().Details)
+	assert.Equal(t, http.StatusInternalServerError, handler.HTTPStatus())
+	assert.Equal(t, OutcomeServerError, handler.Outcome())
+}
+
+func TestFromHTTPResponseBareUnmappedStatus(t *testing.T) {
+	for _, status := range []int{http.StatusInsufficientStorage, http.StatusHTTPVersionNotSupported, 599} {
+		server := serveWithHeaders(t, status, nil, "")
+
+		resp, err := http.Get(server.URL)
+		require.NoError(t, err)
+
+		handler, err := FromHTTPResponseBare(resp)
+		require.NoError(t, err)
+		assert.Equal(t, status, handler.HTTPStatus())
+		assert.True(t, handler.IsServerFault())
+		assert.Equal(t, OutcomeServerError, handler.Outcome())
+	}
+}
+
+func TestFromHTTPResponseBareGobRoundTrip(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusInsufficientStorage, nil, "disk full")
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	original, err := FromHTTPResponseBare(resp)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(original))
+
+	var restored Handler
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&restored))
+	assert.Equal(t, "disk full", restored.GetError().Details)
+	assert.Equal(t, http.StatusInsufficientStorage, restored.HTTPStatus())
+}
+
+func TestFromHTTPResponseBareInvalidJSON(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, nil, "not json")
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	_, err = FromHTTPResponseBare(resp)
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Equal(t, http.StatusOK, valErr.Context["status_code"])
+	assert.Equal(t, http.MethodGet, valErr.Context["method"])
+
+	_, err = FromHTTPResponseBare(nil)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+}
+
+func TestStatusErrorCode(t *testing.T) {
+	assert.Equal(t, "NOT_FOUND", statusErrorCode(http.StatusNotFound))
+	assert.Equal(t, "TOO_MANY_REQUESTS", statusErrorCode(http.StatusTooManyRequests))
+	assert.Equal(t, "IM_A_TEAPOT", statusErrorCode(http.StatusTeapot))
+	assert.Equal(t, "NON_AUTHORITATIVE_INFORMATION", statusErrorCode(http.StatusNonAuthoritativeInfo))
+	assert.Equal(t, "HTTP_599", statusErrorCode(599))
+}
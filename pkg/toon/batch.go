@@ -0,0 +1,108 @@
+package toon
+
+import (
+	"encoding/json"
+	"runtime"
+	"sync"
+)
+
+// NewBatchHandler parses a top-level JSON array of Toon responses into one
+// Handler per element, in order
+// A failing element is reported with its position in the "index" context key
+func NewBatchHandler(body []byte) ([]*Handler, error) {
+	elements, err := splitBatch(body)
+	if err != nil {
+		return nil, err
+	}
+
+	handlers := make([]*Handler, len(elements))
+	for i, element := range elements {
+		h, err := newBatchElement(element, i)
+		if err != nil {
+			return nil, err
+		}
+		handlers[i] = h
+	}
+	return handlers, nil
+}
+
+// NewBatchHandlerConcurrent is like NewBatchHandler but parses the elements
+// across a pool of workers, which pays off for large batches on multicore
+// machines; workers <= 0 uses GOMAXPROCS
+// The result preserves element order, and when several elements fail the
+// error for the lowest index is returned so the outcome is deterministic
+func NewBatchHandlerConcurrent(body []byte, workers int) ([]*Handler, error) {
+	elements, err := splitBatch(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(elements) {
+		workers = len(elements)
+	}
+	if len(elements) == 0 {
+		return []*Handler{}, nil
+	}
+
+	handlers := make([]*Handler, len(elements))
+	errs := make([]error, len(elements))
+
+	// Give each worker a contiguous block so no per-element coordination is needed
+	var wg sync.WaitGroup
+	size := (len(elements) + workers - 1) / workers
+	for start := 0; start < len(elements); start += size {
+		end := min(start+size, len(elements))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				handlers[i], errs[i] = newBatchElement(elements[i], i)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return handlers, nil
+}
+
+// splitBatch splits a top-level JSON array into its raw elements
+func splitBatch(body []byte) ([]json.RawMessage, error) {
+	if len(body) == 0 {
+		return nil, &ValidationError{
+			Code:    ErrCodeEmptyResponse,
+			Message: "body is empty",
+		}
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal(stripBOM(body), &elements); err != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal batch body as an array",
+			Err:     err,
+			Context: map[string]interface{}{
+				"body_size": len(body),
+			},
+		}
+	}
+	return elements, nil
+}
+
+// newBatchElement parses one batch element, recording its index on failure
+func newBatchElement(element json.RawMessage, index int) (*Handler, error) {
+	h, err := NewHandler(element)
+	if err != nil {
+		return nil, withContext(err, map[string]interface{}{
+			"index": index,
+		})
+	}
+	return h, nil
+}
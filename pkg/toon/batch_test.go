@@ -0,0 +1,105 @@
+package toon
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchBody builds a JSON array of n success responses with increasing ids
+func batchBody(n int) []byte {
+	elements := make([]string, n)
+	for i := range elements {
+		elements[i] = fmt.Sprintf(`{"success": true, "data": {"id": %d}, "meta": {"request_id": "req-%d"}}`, i, i)
+	}
+	return []byte("[" + strings.Join(elements, ",") + "]")
+}
+
+func TestNewBatchHandler(t *testing.T) {
+	handlers, err := NewBatchHandler(batchBody(3))
+	require.NoError(t, err)
+	require.Len(t, handlers, 3)
+
+	for i, h := range handlers {
+		assert.True(t, h.IsSuccess())
+		assert.Equal(t, fmt.Sprintf("req-%d", i), h.GetRequestID())
+	}
+}
+
+func TestNewBatchHandlerConcurrentPreservesOrder(t *testing.T) {
+	const count = 500
+
+	for _, workers := range []int{0, 1, 4, count * 2} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			handlers, err := NewBatchHandlerConcurrent(batchBody(count), workers)
+			require.NoError(t, err)
+			require.Len(t, handlers, count)
+
+			for i, h := range handlers {
+				var data struct {
+					ID int `json:"id"`
+				}
+				require.NoError(t, h.UnmarshalData(&data))
+				assert.Equal(t, i, data.ID)
+			}
+		})
+	}
+}
+
+func TestNewBatchHandlerElementError(t *testing.T) {
+	body := []byte(`[{"success": true}, {"success": true}, "bad", {"success": "nope"}]`)
+
+	for name, parse := range map[string]func([]byte) ([]*Handler, error){
+		"sequential": NewBatchHandler,
+		"concurrent": func(b []byte) ([]*Handler, error) { return NewBatchHandlerConcurrent(b, 4) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			handlers, err := parse(body)
+			assert.Nil(t, handlers)
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+			assert.Equal(t, 2, valErr.Context["index"])
+		})
+	}
+}
+
+func TestNewBatchHandlerInvalidBody(t *testing.T) {
+	var valErr *ValidationError
+
+	_, err := NewBatchHandler(nil)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyResponse, valErr.Code)
+
+	_, err = NewBatchHandlerConcurrent([]byte(`{"success": true}`), 2)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+
+	handlers, err := NewBatchHandlerConcurrent([]byte(`[]`), 2)
+	require.NoError(t, err)
+	assert.Empty(t, handlers)
+}
+
+func BenchmarkNewBatchHandler(b *testing.B) {
+	body := batchBody(5000)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewBatchHandler(body); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("concurrent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewBatchHandlerConcurrent(body, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
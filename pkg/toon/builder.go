@@ -0,0 +1,186 @@
+package toon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseBuilder builds Toon response bodies for servers and tests
+// Methods return the builder for chaining; the first error encountered is
+// reported by Build
+type ResponseBuilder struct {
+	resp       Response
+	metaFields map[string]json.RawMessage
+	gzip       bool
+	err        error
+}
+
+// NewResponseBuilder creates a builder for a successful response with no data
+func NewResponseBuilder() *ResponseBuilder {
+	return &ResponseBuilder{resp: Response{Success: true}}
+}
+
+// WithData marshals v as the response data and marks the response successful
+func (b *ResponseBuilder) WithData(v interface{}) *ResponseBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		if b.err == nil {
+			b.err = &ValidationError{
+				Code:    ErrCodeJSONMarshal,
+				Message: "failed to marshal response data",
+				Err:     err,
+				Context: map[string]interface{}{
+					"source": fmt.Sprintf("%T", v),
+				},
+			}
+		}
+		return b
+	}
+
+	b.resp.Success = true
+	b.resp.Data = data
+	return b
+}
+
+// WithError sets the response error and marks the response unsuccessful
+func (b *ResponseBuilder) WithError(code, message string) *ResponseBuilder {
+	b.resp.Success = false
+	b.resp.Error = &ResponseError{Code: code, Message: message}
+	return b
+}
+
+// WithMeta sets the response metadata
+func (b *ResponseBuilder) WithMeta(meta *Meta) *ResponseBuilder {
+	b.resp.Meta = meta
+	return b
+}
+
+// WithMetaField adds a vendor-specific meta field, serialized alongside the
+// known meta fields and readable by clients via MetaField
+// Keys of known meta fields such as "request_id" are rejected so they cannot
+// be clobbered; use WithMeta to set those
+func (b *ResponseBuilder) WithMetaField(key string, value interface{}) *ResponseBuilder {
+	if isMetaField(key) {
+		if b.err == nil {
+			b.err = &ValidationError{
+				Code:    ErrCodeInvalidResponse,
+				Message: "meta field is a known meta field",
+				Context: map[string]interface{}{
+					"key": key,
+				},
+			}
+		}
+		return b
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		if b.err == nil {
+			b.err = &ValidationError{
+				Code:    ErrCodeJSONMarshal,
+				Message: "failed to marshal meta field",
+				Err:     err,
+				Context: map[string]interface{}{
+					"key":    key,
+					"source": fmt.Sprintf("%T", value),
+				},
+			}
+		}
+		return b
+	}
+
+	if b.metaFields == nil {
+		b.metaFields = make(map[string]json.RawMessage)
+	}
+	b.metaFields[key] = raw
+	return b
+}
+
+// WithGzip makes WriteTo gzip-compress the body and set Content-Encoding
+func (b *ResponseBuilder) WithGzip() *ResponseBuilder {
+	b.gzip = true
+	return b
+}
+
+// Build returns the JSON encoded response
+func (b *ResponseBuilder) Build() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.metaFields) == 0 {
+		return json.Marshal(&b.resp)
+	}
+
+	// Merge into a copy so the Meta passed to WithMeta is left untouched
+	resp := b.resp
+	meta := Meta{}
+	if resp.Meta != nil {
+		meta = *resp.Meta
+	}
+	meta.Extra = make(map[string]json.RawMessage, len(meta.Extra)+len(b.metaFields))
+	if resp.Meta != nil {
+		for k, v := range resp.Meta.Extra {
+			meta.Extra[k] = v
+		}
+	}
+	for k, v := range b.metaFields {
+		meta.Extra[k] = v
+	}
+	resp.Meta = &meta
+	return json.Marshal(&resp)
+}
+
+// BuildGzip returns the gzip-compressed JSON encoded response
+func (b *ResponseBuilder) BuildGzip() ([]byte, error) {
+	body, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTo writes the built response to w
+// When w is an http.ResponseWriter, the content type, the Content-Encoding for
+// gzip bodies and the status from HTTPStatus are written first
+func (b *ResponseBuilder) WriteTo(w io.Writer) (int64, error) {
+	body, err := b.Build()
+	if err != nil {
+		return 0, err
+	}
+
+	out := body
+	if b.gzip {
+		if out, err = b.BuildGzip(); err != nil {
+			return 0, err
+		}
+	}
+
+	if rw, ok := w.(http.ResponseWriter); ok {
+		status := http.StatusOK
+		if handler, err := NewHandler(body); err == nil {
+			status = handler.HTTPStatus()
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if b.gzip {
+			rw.Header().Set("Content-Encoding", "gzip")
+		}
+		rw.WriteHeader(status)
+	}
+
+	n, err := w.Write(out)
+	return int64(n), err
+}
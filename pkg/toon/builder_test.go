@@ -0,0 +1,134 @@
+package toon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseBuilder(t *testing.T) {
+	body, err := NewResponseBuilder().
+		WithData(map[string]int{"id": 1}).
+		WithMeta(&Meta{RequestID: "req-1"}).
+		Build()
+	require.NoError(t, err)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "req-1", handler.GetRequestID())
+	assert.JSONEq(t, `{"id": 1}`, string(handler.GetData()))
+
+	body, err = NewResponseBuilder().WithError("NOT_FOUND", "missing").Build()
+	require.NoError(t, err)
+
+	handler, err = NewHandler(body)
+	require.NoError(t, err)
+	assert.Equal(t, "NOT_FOUND | missing", handler.ErrorString())
+
+	_, err = NewResponseBuilder().WithData(make(chan int)).Build()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONMarshal, valErr.Code)
+}
+
+func TestResponseBuilderBuildGzip(t *testing.T) {
+	builder := NewResponseBuilder().WithData([]string{"a", "b"})
+
+	compressed, err := builder.BuildGzip()
+	require.NoError(t, err)
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(gz)
+	require.NoError(t, err)
+
+	plain, err := builder.Build()
+	require.NoError(t, err)
+	assert.Equal(t, plain, decompressed)
+}
+
+func TestResponseBuilderGzipRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = NewResponseBuilder().
+			WithData(map[string]string{"name": "test"}).
+			WithMeta(&Meta{RequestID: "req-gz"}).
+			WithGzip().
+			WriteTo(w)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	// Setting Accept-Encoding explicitly disables transparent decompression
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "req-gz", handler.GetRequestID())
+	assert.JSONEq(t, `{"name": "test"}`, string(handler.GetData()))
+}
+
+func TestResponseBuilderWriteToStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	_, err := NewResponseBuilder().WithError("RATE_LIMITED", "slow down").WriteTo(rec)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+}
+
+func TestResponseBuilderWithMetaFieldRoundTrip(t *testing.T) {
+	meta := &Meta{RequestID: "req-1"}
+	body, err := NewResponseBuilder().
+		WithData(map[string]int{"id": 1}).
+		WithMetaField("region", "eu-west-1").
+		WithMeta(meta).
+		WithMetaField("quota", map[string]int{"used": 3}).
+		Build()
+	require.NoError(t, err)
+	assert.Nil(t, meta.Extra)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", handler.GetRequestID())
+
+	region, ok := handler.MetaField("region")
+	require.True(t, ok)
+	assert.JSONEq(t, `"eu-west-1"`, string(region))
+
+	quota, ok := handler.MetaField("quota")
+	require.True(t, ok)
+	assert.JSONEq(t, `{"used": 3}`, string(quota))
+
+	_, ok = handler.MetaField("missing")
+	assert.False(t, ok)
+	_, ok = handler.MetaField("request_id")
+	assert.False(t, ok)
+}
+
+func TestResponseBuilderWithMetaFieldRejectsKnownKeys(t *testing.T) {
+	_, err := NewResponseBuilder().
+		WithMeta(&Meta{RequestID: "req-1"}).
+		WithMetaField("request_id", "clobbered").
+		Build()
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+	assert.Equal(t, "request_id", valErr.Context["key"])
+
+	_, err = NewResponseBuilder().WithMetaField("bad", make(chan int)).Build()
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONMarshal, valErr.Code)
+}
@@ -0,0 +1,126 @@
+package toon
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// loadCachedData copies a cached decode for v's type into v
+// Returns false if caching is disabled or nothing is cached for the type
+func (h *Handler) loadCachedData(v interface{}) bool {
+	if h == nil {
+		return false
+	}
+
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return false
+	}
+
+	h.mu.RLock()
+	cached, ok := h.dataCache[target.Type()]
+	h.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	target.Elem().Set(deepCopyValue(cached))
+	return true
+}
+
+// decodeCachedData decodes data into a fresh value of v's type, caches a
+// deep copy of it and then stores it in v
+// Decoding into a fresh value keeps fields the caller preset in v out of the
+// cache; v is replaced as a whole, as on a cache hit
+// Returns false without decoding unless the handler was created with
+// WithDecodeCache and v is a non-nil pointer
+func (h *Handler) decodeCachedData(data []byte, v interface{}) (bool, error) {
+	if h == nil || !h.options().decodeCache {
+		return false, nil
+	}
+
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return false, nil
+	}
+
+	fresh := reflect.New(target.Type().Elem())
+	if err := json.Unmarshal(data, fresh.Interface()); err != nil {
+		return true, decodeError(err, len(data), v)
+	}
+	cached := deepCopyValue(fresh.Elem())
+
+	h.mu.Lock()
+	if h.dataCache == nil {
+		h.dataCache = make(map[reflect.Type]reflect.Value)
+	}
+	h.dataCache[target.Type()] = cached
+	h.mu.Unlock()
+
+	target.Elem().Set(fresh.Elem())
+	return true, nil
+}
+
+// deepCopyValue returns a copy of v that shares no maps, slices or pointers
+// with it; unexported struct fields, which encoding/json never sets, are
+// copied shallowly
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopyValue(v.Elem()))
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopyValue(v.Elem()))
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := c.Field(i); field.CanSet() {
+				field.Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return c
+
+	default:
+		return v
+	}
+}
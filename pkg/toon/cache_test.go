@@ -0,0 +1,127 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedUser struct {
+	ID    int               `json:"id"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]string `json:"attrs"`
+	Boss  *cachedUser       `json:"boss"`
+}
+
+const cachedUserBody = `{
+	"success": true,
+	"data": {
+		"id": 1,
+		"tags": ["a", "b"],
+		"attrs": {"role": "admin"},
+		"boss": {"id": 2, "tags": ["c"]}
+	}
+}`
+
+func TestDecodeCacheReturnsSameValue(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(cachedUserBody), WithDecodeCache())
+	require.NoError(t, err)
+
+	var first, second cachedUser
+	require.NoError(t, handler.UnmarshalData(&first))
+	require.NoError(t, handler.UnmarshalData(&second))
+	assert.Equal(t, first, second)
+	assert.Len(t, handler.dataCache, 1)
+
+	var generic map[string]interface{}
+	require.NoError(t, handler.UnmarshalData(&generic))
+	assert.EqualValues(t, 1, generic["id"])
+	assert.Len(t, handler.dataCache, 2)
+}
+
+func TestDecodeCacheNotMutatedByCallers(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(cachedUserBody), WithDecodeCache())
+	require.NoError(t, err)
+
+	var first cachedUser
+	require.NoError(t, handler.UnmarshalData(&first))
+	first.ID = 99
+	first.Tags[0] = "mutated"
+	first.Attrs["role"] = "mutated"
+	first.Boss.Tags[0] = "mutated"
+
+	var second cachedUser
+	require.NoError(t, handler.UnmarshalData(&second))
+	second.Tags = append(second.Tags, "extra")
+
+	var third cachedUser
+	require.NoError(t, handler.UnmarshalData(&third))
+	assert.Equal(t, 1, third.ID)
+	assert.Equal(t, []string{"a", "b"}, third.Tags)
+	assert.Equal(t, "admin", third.Attrs["role"])
+	assert.Equal(t, []string{"c"}, third.Boss.Tags)
+
+	var generic map[string]interface{}
+	require.NoError(t, handler.UnmarshalData(&generic))
+	generic["tags"].([]interface{})[0] = "mutated"
+
+	var genericAgain map[string]interface{}
+	require.NoError(t, handler.UnmarshalData(&genericAgain))
+	assert.Equal(t, "a", genericAgain["tags"].([]interface{})[0])
+}
+
+func TestDecodeCacheDisabledByDefault(t *testing.T) {
+	handler, err := NewHandler([]byte(cachedUserBody))
+	require.NoError(t, err)
+
+	var user cachedUser
+	require.NoError(t, handler.UnmarshalData(&user))
+	assert.Nil(t, handler.dataCache)
+}
+
+func BenchmarkUnmarshalDataRepeated(b *testing.B) {
+	b.Run("no cache", func(b *testing.B) {
+		handler, err := NewHandler([]byte(cachedUserBody))
+		require.NoError(b, err)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var user cachedUser
+			if err := handler.UnmarshalData(&user); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cache", func(b *testing.B) {
+		handler, err := NewHandlerWithOptions([]byte(cachedUserBody), WithDecodeCache())
+		require.NoError(b, err)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var user cachedUser
+			if err := handler.UnmarshalData(&user); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestDecodeCacheIgnoresPresetFields(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{"success": true, "data": {"id": 1}}`), WithDecodeCache())
+	require.NoError(t, err)
+
+	type withExtra struct {
+		ID    int    `json:"id"`
+		Extra string `json:"extra"`
+	}
+
+	preset := withExtra{Extra: "caller-default"}
+	require.NoError(t, handler.UnmarshalData(&preset))
+	assert.Equal(t, 1, preset.ID)
+
+	var next withExtra
+	require.NoError(t, handler.UnmarshalData(&next))
+	assert.Equal(t, withExtra{ID: 1}, next)
+}
@@ -0,0 +1,131 @@
+package toon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// DataChecksum returns a hex encoded SHA-256 of the normalized data payload
+// The data is decoded and re-marshaled canonically so that key order and
+// whitespace do not affect the result
+// Returns empty string if no data is present or the data cannot be normalized
+func (h *Handler) DataChecksum() string {
+	data := h.GetData()
+	if len(data) == 0 {
+		return ""
+	}
+
+	normalized, err := normalizeJSON(data)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeJSON re-encodes JSON with sorted object keys and no insignificant whitespace
+// Numbers are rewritten in a canonical form without going through float64,
+// so 1, 1.0 and 1e0 normalize alike while large integers keep their precision
+func normalizeJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(canonicalizeNumbers(v))
+}
+
+// canonicalizeNumbers replaces every json.Number in v with its canonicalNumber
+func canonicalizeNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		return canonicalNumber(t)
+	case map[string]interface{}:
+		for key, value := range t {
+			t[key] = canonicalizeNumbers(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = canonicalizeNumbers(value)
+		}
+	}
+	return v
+}
+
+// canonicalNumber rewrites a JSON number as its significant digits followed
+// by a decimal exponent, e.g. 1.50 becomes 15e-1 and 100 becomes 1e2
+// Numbers whose exponent does not fit an int are returned unchanged
+func canonicalNumber(n json.Number) json.Number {
+	s := string(n)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(strings.TrimPrefix(s[i+1:], "+"))
+		if err != nil {
+			return n
+		}
+		exp, s = e, s[:i]
+	}
+
+	digits := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		exp -= len(s) - i - 1
+	}
+
+	digits = strings.TrimLeft(digits, "0")
+	trimmed := strings.TrimRight(digits, "0")
+	exp += len(digits) - len(trimmed)
+	digits = trimmed
+
+	if digits == "" {
+		return "0"
+	}
+	if negative {
+		digits = "-" + digits
+	}
+	if exp == 0 {
+		return json.Number(digits)
+	}
+	return json.Number(digits + "e" + strconv.Itoa(exp))
+}
+
+// Fingerprint returns a fast non-cryptographic FNV-1a hash of the response
+// for deduplicating functionally identical events
+// It covers the success flag, the error object and the normalized data;
+// meta is excluded entirely, so volatile fields such as timestamp and
+// request_id do not affect the result
+func (h *Handler) Fingerprint() uint64 {
+	hash := fnv.New64a()
+
+	if h.IsSuccess() {
+		hash.Write([]byte{1})
+	} else {
+		hash.Write([]byte{0})
+	}
+
+	for _, part := range [][]byte{h.GetErrorRaw(), h.GetData()} {
+		// Separate sections so that data and error bytes cannot collide
+		hash.Write([]byte{0xff})
+		if len(part) == 0 {
+			continue
+		}
+		if normalized, err := normalizeJSON(part); err == nil {
+			part = normalized
+		}
+		hash.Write(part)
+	}
+
+	return hash.Sum64()
+}
@@ -0,0 +1,108 @@
+package toon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChecksumIgnoresLayout(t *testing.T) {
+	first, err := NewHandler([]byte(`{
+		"success": true,
+		"data": {"id": 1, "name": "test", "tags": ["a", "b"]},
+		"meta": {"request_id": "req-1"}
+	}`))
+	require.NoError(t, err)
+
+	second, err := NewHandler([]byte(`{"success":true,"data":{"tags":["a","b"],"name":"test","id":1},"meta":{"request_id":"req-2"}}`))
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, first.DataChecksum())
+	assert.Len(t, first.DataChecksum(), 64)
+	assert.Equal(t, first.DataChecksum(), second.DataChecksum())
+}
+
+func TestDataChecksumDetectsChanges(t *testing.T) {
+	first, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	second, err := NewHandler([]byte(`{"success": true, "data": {"id": 2}}`))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.DataChecksum(), second.DataChecksum())
+}
+
+func TestDataChecksumEquivalentNumbers(t *testing.T) {
+	checksum := func(data string) string {
+		handler, err := NewHandler([]byte(`{"success": true, "data": ` + data + `}`))
+		require.NoError(t, err)
+		return handler.DataChecksum()
+	}
+
+	assert.Equal(t, checksum(`{"amount": 1}`), checksum(`{"amount": 1.0}`))
+	assert.Equal(t, checksum(`{"amount": 1.5}`), checksum(`{"amount": 15e-1}`))
+	assert.Equal(t, checksum(`[0, 0.0, -0]`), checksum(`[0, 0, 0]`))
+	assert.NotEqual(t, checksum(`{"id": 9007199254740993}`), checksum(`{"id": 9007199254740992}`))
+}
+
+func TestCanonicalNumber(t *testing.T) {
+	tests := map[string]string{
+		"1":      "1",
+		"1.0":    "1",
+		"1.50":   "15e-1",
+		"100":    "1e2",
+		"1E+2":   "1e2",
+		"-0.001": "-1e-3",
+		"0.0":    "0",
+		"-0":     "0",
+		"12e-1":  "12e-1",
+	}
+	for in, want := range tests {
+		assert.Equal(t, json.Number(want), canonicalNumber(json.Number(in)), in)
+	}
+}
+
+func TestDataChecksumWithoutData(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, handler.DataChecksum())
+}
+
+func TestFingerprintIgnoresVolatileMeta(t *testing.T) {
+	first, err := NewHandler([]byte(`{
+		"success": true,
+		"data": {"event": "created", "id": 1},
+		"meta": {"request_id": "req-1", "timestamp": "2025-01-01T00:00:00Z"}
+	}`))
+	require.NoError(t, err)
+
+	second, err := NewHandler([]byte(`{
+		"success": true,
+		"data": {"id": 1, "event": "created"},
+		"meta": {"request_id": "req-2", "timestamp": "2025-06-01T12:30:00Z"}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Fingerprint(), second.Fingerprint())
+}
+
+func TestFingerprintDetectsChanges(t *testing.T) {
+	base, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	changedData, err := NewHandler([]byte(`{"success": true, "data": {"id": 2}}`))
+	require.NoError(t, err)
+
+	failed, err := NewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "msg"}}`))
+	require.NoError(t, err)
+
+	otherError, err := NewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "other"}}`))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, base.Fingerprint(), changedData.Fingerprint())
+	assert.NotEqual(t, base.Fingerprint(), failed.Fingerprint())
+	assert.NotEqual(t, failed.Fingerprint(), otherError.Fingerprint())
+}
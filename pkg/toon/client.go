@@ -0,0 +1,203 @@
+package toon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Do sends the request with the given client and parses the response
+// Canceling ctx aborts the request, including a body read in progress, and
+// yields a ValidationError with ErrCodeRequestCanceled
+// A nil client uses http.DefaultClient
+func Do(ctx context.Context, client *http.Client, req *http.Request) (*Handler, error) {
+	handler, _, err := DoTimed(ctx, client, req)
+	return handler, err
+}
+
+// DoTimed sends the request with the given client and parses the response
+// The wall-clock time spent in client.Do is returned and recorded on the handler
+// A nil client uses http.DefaultClient
+func DoTimed(ctx context.Context, client *http.Client, req *http.Request) (*Handler, time.Duration, error) {
+	req, httpResp, latency, err := send(ctx, client, req)
+	if err != nil {
+		return nil, latency, err
+	}
+
+	handler, err := fromHTTPResponse(req.Context(), httpResp, handlerOptions{})
+	if err != nil {
+		return nil, latency, err
+	}
+
+	handler.mu.Lock()
+	handler.latency = latency
+	handler.mu.Unlock()
+
+	return handler, latency, nil
+}
+
+// send performs req with client, returning the request as sent
+func send(ctx context.Context, client *http.Client, req *http.Request) (*http.Request, *http.Response, time.Duration, error) {
+	if req == nil {
+		return nil, nil, 0, &ValidationError{
+			Code:    ErrCodeRequestFailed,
+			Message: "http request is nil",
+		}
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	httpResp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		// The transport reports a canceled request as a generic failure
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return req, nil, latency, &ValidationError{
+				Code:    ErrCodeRequestCanceled,
+				Message: "request canceled",
+				Err:     ctxErr,
+				Context: requestContext(req),
+			}
+		}
+		return req, nil, latency, &ValidationError{
+			Code:    ErrCodeRequestFailed,
+			Message: "http request failed",
+			Err:     err,
+			Context: requestContext(req),
+		}
+	}
+	return req, httpResp, latency, nil
+}
+
+// EmptyBodyRetryBackoff is the delay before the first retry in
+// DoWithEmptyBodyRetry; each further retry waits one more multiple of it
+var EmptyBodyRetryBackoff = 50 * time.Millisecond
+
+// DoWithEmptyBodyRetry sends a request built by newReq and retries up to
+// maxRetries times while a 2xx response has an empty or whitespace-only body,
+// which some upstreams occasionally return due to a race
+// newReq is called for every attempt so request bodies can be replayed
+// Non-2xx responses and other errors are returned immediately; once the
+// retries are used up an empty body yields ErrCodeEmptyResponse; canceling
+// ctx stops the retries
+func DoWithEmptyBodyRetry(ctx context.Context, client *http.Client, newReq func() (*http.Request, error), maxRetries int) (*Handler, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, &ValidationError{
+				Code:    ErrCodeRequestFailed,
+				Message: "failed to build http request",
+				Err:     err,
+				Context: map[string]interface{}{
+					"attempt": attempt + 1,
+				},
+			}
+		}
+
+		handler, empty, err := doDetectEmpty(ctx, client, req)
+		if !empty || attempt >= maxRetries {
+			return handler, err
+		}
+
+		timer := time.NewTimer(time.Duration(attempt+1) * EmptyBodyRetryBackoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &ValidationError{
+				Code:    ErrCodeRequestCanceled,
+				Message: "request canceled while waiting to retry empty body",
+				Err:     ctx.Err(),
+				Context: map[string]interface{}{
+					"attempt": attempt + 1,
+				},
+			}
+		case <-timer.C:
+		}
+	}
+}
+
+// doDetectEmpty is like Do but reports empty, with an ErrCodeEmptyResponse
+// error, when a 2xx response has an empty or whitespace-only body
+func doDetectEmpty(ctx context.Context, client *http.Client, req *http.Request) (handler *Handler, empty bool, err error) {
+	req, httpResp, latency, err := send(ctx, client, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	body, err := readHTTPBody(req.Context(), httpResp)
+	if err != nil {
+		return nil, false, withContext(err, requestContext(httpResp.Request))
+	}
+
+	if httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 &&
+		len(bytes.TrimSpace(stripBOM(body))) == 0 {
+		return nil, true, withContext(&ValidationError{
+			Code:    ErrCodeEmptyResponse,
+			Message: "body is empty or contains only whitespace",
+			Context: map[string]interface{}{
+				"status_code": httpResp.StatusCode,
+				"body_size":   len(body),
+			},
+		}, requestContext(httpResp.Request))
+	}
+
+	handler, err = parseHTTPBody(httpResp, body, handlerOptions{})
+	if err != nil {
+		return nil, false, withContext(err, requestContext(httpResp.Request))
+	}
+
+	handler.mu.Lock()
+	handler.latency = latency
+	handler.mu.Unlock()
+
+	return handler, false, nil
+}
+
+// Latency returns the upstream round trip time recorded by DoTimed
+// Returns zero for handlers built without timing
+func (h *Handler) Latency() time.Duration {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.latency
+}
+
+// Check performs a health probe with the given request
+// It returns (false, "transport") when the request could not be sent or
+// completed, including when ctx is canceled or times out,
+// (false, code) when the response could not be parsed or carries an error,
+// and (true, "") on success
+func Check(ctx context.Context, client *http.Client, req *http.Request) (ok bool, reason string) {
+	handler, _, err := DoTimed(ctx, client, req)
+	if err != nil {
+		var valErr *ValidationError
+		if errors.As(err, &valErr) && valErr.Code != ErrCodeRequestFailed && valErr.Code != ErrCodeRequestCanceled {
+			return false, string(valErr.Code)
+		}
+		return false, "transport"
+	}
+
+	if handler.IsSuccess() {
+		return true, ""
+	}
+
+	if respErr := handler.GetError(); respErr != nil && respErr.Code != "" {
+		return false, respErr.Code
+	}
+	return false, string(ErrCodeInvalidResponse)
+}
@@ -0,0 +1,292 @@
+package toon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoTimedRecordsLatency(t *testing.T) {
+	delay := 50 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	handler, latency, err := DoTimed(context.Background(), server.Client(), req)
+	require.NoError(t, err)
+	require.NotNil(t, handler)
+	assert.True(t, handler.IsSuccess())
+	assert.GreaterOrEqual(t, latency, delay)
+	assert.Equal(t, latency, handler.Latency())
+}
+
+func TestDoTimedTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	handler, _, err := DoTimed(context.Background(), nil, req)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeRequestFailed, valErr.Code)
+}
+
+func TestLatencyWithoutTiming(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	assert.Zero(t, handler.Latency())
+}
+
+func TestCheck(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer healthy.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"success": false, "error": {"code": "SERVICE_UNAVAILABLE", "message": "down for maintenance"}}`))
+	}))
+	defer failing.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		timeout time.Duration
+		ok      bool
+		reason  string
+	}{
+		{name: "healthy", url: healthy.URL, ok: true, reason: ""},
+		{name: "error response", url: failing.URL, ok: false, reason: "SERVICE_UNAVAILABLE"},
+		{name: "server down", url: down.URL, ok: false, reason: "transport"},
+		{name: "timeout", url: slow.URL, timeout: 50 * time.Millisecond, ok: false, reason: "transport"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			ok, reason := Check(ctx, nil, req)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestDoCanceledDuringBodyRead(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true, "data": [`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	handler, err := Do(ctx, server.Client(), req)
+	assert.Nil(t, handler)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeRequestCanceled, valErr.Code)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDoCanceledBeforeResponse(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	handler, err := Do(ctx, server.Client(), req)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeRequestCanceled, valErr.Code)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, http.MethodGet, valErr.Context["method"])
+}
+
+func TestDoWithEmptyBodyRetry(t *testing.T) {
+	original := EmptyBodyRetryBackoff
+	EmptyBodyRetryBackoff = time.Millisecond
+	t.Cleanup(func() { EmptyBodyRetryBackoff = original })
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusOK)
+		case 2:
+			w.Write([]byte("  \n"))
+		default:
+			w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		}
+	}))
+	defer server.Close()
+
+	newReq := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	handler, err := DoWithEmptyBodyRetry(context.Background(), server.Client(), newReq, 3)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDoWithEmptyBodyRetryExhausted(t *testing.T) {
+	original := EmptyBodyRetryBackoff
+	EmptyBodyRetryBackoff = time.Millisecond
+	t.Cleanup(func() { EmptyBodyRetryBackoff = original })
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	newReq := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	_, err := DoWithEmptyBodyRetry(context.Background(), server.Client(), newReq, 2)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyResponse, valErr.Code)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDoWithEmptyBodyRetryOtherErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{not json`))
+	}))
+	defer server.Close()
+
+	newReq := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	_, err := DoWithEmptyBodyRetry(context.Background(), server.Client(), newReq, 3)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = DoWithEmptyBodyRetry(context.Background(), server.Client(), func() (*http.Request, error) {
+		return nil, errors.New("no request")
+	}, 3)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeRequestFailed, valErr.Code)
+}
+
+func TestDoWithEmptyBodyRetryNon2xx(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	newReq := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	_, err := DoWithEmptyBodyRetry(context.Background(), server.Client(), newReq, 3)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyResponse, valErr.Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDoWithEmptyBodyRetryCanceled(t *testing.T) {
+	original := EmptyBodyRetryBackoff
+	EmptyBodyRetryBackoff = time.Hour
+	t.Cleanup(func() { EmptyBodyRetryBackoff = original })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	newReq := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	_, err := DoWithEmptyBodyRetry(ctx, server.Client(), newReq, 3)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeRequestCanceled, valErr.Code)
+}
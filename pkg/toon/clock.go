@@ -0,0 +1,34 @@
+package toon
+
+import "time"
+
+// Now is the time source used by the rate limit, retry, freshness, clock skew and
+// deprecation helpers
+// It exists so tests can inject a fixed clock; production code should leave
+// it as time.Now, and it must not be changed while handlers are in use
+var Now = time.Now
+
+// ClockSkew returns how far the server clock is ahead of the local clock:
+// Meta.Timestamp minus the local time the response was parsed
+// A negative skew means the server clock is behind; the value also includes
+// the time the response spent in transit, so only large skews are meaningful
+// Returns false when the response has no server timestamp
+func (h *Handler) ClockSkew() (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+
+	meta := h.GetMeta()
+	if meta == nil || meta.Timestamp.IsZero() {
+		return 0, false
+	}
+
+	h.mu.RLock()
+	parsedAt := h.parsedAt
+	h.mu.RUnlock()
+
+	if parsedAt.IsZero() {
+		return 0, false
+	}
+	return meta.Timestamp.Sub(parsedAt), true
+}
@@ -0,0 +1,131 @@
+package toon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedNow makes Now return t for the rest of the test
+func fixedNow(tb testing.TB, t time.Time) {
+	tb.Helper()
+
+	original := Now
+	Now = func() time.Time { return t }
+	tb.Cleanup(func() { Now = original })
+}
+
+func TestRetryAfterWithFixedClock(t *testing.T) {
+	fixedNow(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	handler, err := NewHandler([]byte(`{
+		"success": true,
+		"meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "2025-06-01T12:01:30Z"}}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, 90*time.Second, handler.RetryAfter())
+	assert.False(t, handler.CanRetryNow())
+
+	fixedNow(t, time.Date(2025, 6, 1, 12, 2, 0, 0, time.UTC))
+	assert.Equal(t, time.Duration(0), handler.RetryAfter())
+	assert.True(t, handler.CanRetryNow())
+}
+
+func TestRetryAfterHeaderDateWithFixedClock(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedNow(t, now)
+
+	header := http.Header{}
+	header.Set("Retry-After", now.Add(45*time.Second).Format(http.TimeFormat))
+	server := serveWithHeaders(t, http.StatusTooManyRequests, header,
+		`{"success": false, "error": {"code": "RATE_LIMITED", "message": "slow down"}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, handler.RetryAfter())
+}
+
+func TestIsFreshWithFixedClock(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedNow(t, now)
+
+	header := http.Header{}
+	header.Set("Cache-Control", "max-age=60")
+	server := serveWithHeaders(t, http.StatusOK, header, `{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	expiresAt, ok := handler.ExpiresAt()
+	require.True(t, ok)
+	assert.Equal(t, now.Add(time.Minute), expiresAt)
+	assert.True(t, handler.IsFresh())
+
+	fixedNow(t, now.Add(time.Minute))
+	assert.False(t, handler.IsFresh())
+}
+
+func TestClockSkew(t *testing.T) {
+	fixedNow(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	ahead, err := NewHandler([]byte(`{"success": true, "meta": {"timestamp": "2025-06-01T12:00:45Z"}}`))
+	require.NoError(t, err)
+	behind, err := NewHandler([]byte(`{"success": true, "meta": {"timestamp": "2025-06-01T11:58:00Z"}}`))
+	require.NoError(t, err)
+
+	// Skew is measured against the parse time, not the time of the call
+	fixedNow(t, time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC))
+
+	skew, ok := ahead.ClockSkew()
+	assert.True(t, ok)
+	assert.Equal(t, 45*time.Second, skew)
+
+	skew, ok = behind.ClockSkew()
+	assert.True(t, ok)
+	assert.Equal(t, -2*time.Minute, skew)
+}
+
+func TestClockSkewWithoutTimestamp(t *testing.T) {
+	for _, body := range []string{
+		`{"success": true}`,
+		`{"success": true, "meta": {"request_id": "req-1"}}`,
+	} {
+		handler, err := NewHandler([]byte(body))
+		require.NoError(t, err)
+
+		_, ok := handler.ClockSkew()
+		assert.False(t, ok, body)
+	}
+
+	var nilHandler *Handler
+	_, ok := nilHandler.ClockSkew()
+	assert.False(t, ok)
+}
+
+func TestClockSkewSurvivesGob(t *testing.T) {
+	fixedNow(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"timestamp": "2025-06-01T12:00:10Z"}}`))
+	require.NoError(t, err)
+
+	data, err := handler.GobEncode()
+	require.NoError(t, err)
+
+	fixedNow(t, time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC))
+	var decoded Handler
+	require.NoError(t, decoded.GobDecode(data))
+
+	skew, ok := decoded.ClockSkew()
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, skew)
+}
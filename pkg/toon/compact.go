@@ -0,0 +1,44 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// CompactBody returns the body with insignificant whitespace removed, e.g.
+// to shrink pretty-printed responses before caching them
+// Key order, numbers and string contents are left exactly as sent, and a
+// leading byte order mark is dropped
+func (h *Handler) CompactBody() ([]byte, error) {
+	if h == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	h.mu.RLock()
+	body := stripBOM(h.body)
+	h.mu.RUnlock()
+
+	if len(body) == 0 {
+		return nil, &ValidationError{
+			Code:    ErrCodeEmptyResponse,
+			Message: "body is empty",
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(body))
+	if err := json.Compact(&buf, body); err != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to compact response body",
+			Err:     err,
+			Context: map[string]interface{}{
+				"body_size": len(body),
+			},
+		}
+	}
+	return buf.Bytes(), nil
+}
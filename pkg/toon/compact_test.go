@@ -0,0 +1,58 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactBody(t *testing.T) {
+	pretty := []byte(`{
+    "success": true,
+    "data": {
+        "name": "a  b",
+        "price": 1.50,
+        "tags": [ "x", "y" ]
+    },
+    "meta": {
+        "request_id": "req-1"
+    }
+}`)
+
+	handler, err := NewHandler(pretty)
+	require.NoError(t, err)
+
+	compact, err := handler.CompactBody()
+	require.NoError(t, err)
+	assert.Less(t, len(compact), len(pretty))
+	assert.Equal(t, `{"success":true,"data":{"name":"a  b","price":1.50,"tags":["x","y"]},"meta":{"request_id":"req-1"}}`, string(compact))
+	assert.Equal(t, pretty, handler.RawBody())
+
+	reparsed, err := NewHandler(compact)
+	require.NoError(t, err)
+	assert.True(t, reparsed.IsSuccess())
+	assert.Equal(t, "req-1", reparsed.GetRequestID())
+	assert.Equal(t, handler.DataChecksum(), reparsed.DataChecksum())
+}
+
+func TestCompactBodyStripsBOM(t *testing.T) {
+	handler, err := NewHandler([]byte("\xEF\xBB\xBF{ \"success\": true }"))
+	require.NoError(t, err)
+
+	compact, err := handler.CompactBody()
+	require.NoError(t, err)
+	assert.Equal(t, `{"success":true}`, string(compact))
+}
+
+func TestCompactBodyErrors(t *testing.T) {
+	var nilHandler *Handler
+	_, err := nilHandler.CompactBody()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+
+	_, err = (&Handler{}).CompactBody()
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyResponse, valErr.Code)
+}
@@ -0,0 +1,46 @@
+package toon
+
+import "encoding/json"
+
+// FieldConstraint is a machine-readable validation rule for one field, as
+// sent in a "constraints" array on the error, e.g.
+// {"field": "age", "rule": "min", "value": 18, "message": "must be an adult"}
+type FieldConstraint struct {
+	Field   string          `json:"field"`
+	Rule    string          `json:"rule"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+// Constraints decodes the "constraints" array from the error extra so UIs can
+// render validation rules such as min, max or pattern
+// Returns an empty slice if there is no error or it carries no constraints,
+// and a ValidationError if the constraints are malformed
+func (h *Handler) Constraints() ([]FieldConstraint, error) {
+	constraints := []FieldConstraint{}
+	if h == nil {
+		return constraints, nil
+	}
+
+	respErr := h.GetError()
+	if respErr == nil {
+		return constraints, nil
+	}
+
+	raw, ok := respErr.Extra["constraints"]
+	if !ok || string(raw) == "null" {
+		return constraints, nil
+	}
+
+	if err := json.Unmarshal(raw, &constraints); err != nil {
+		return []FieldConstraint{}, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal error constraints",
+			Err:     err,
+			Context: map[string]interface{}{
+				"constraints_size": len(raw),
+			},
+		}
+	}
+	return constraints, nil
+}
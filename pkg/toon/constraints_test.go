@@ -0,0 +1,75 @@
+package toon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {
+			"code": "VALIDATION_ERROR",
+			"message": "invalid input",
+			"constraints": [
+				{"field": "age", "rule": "min", "value": 18, "message": "must be at least 18"},
+				{"field": "age", "rule": "max", "value": 120},
+				{"field": "username", "rule": "pattern", "value": "^[a-z0-9_]+$"}
+			]
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	constraints, err := handler.Constraints()
+	require.NoError(t, err)
+	require.Len(t, constraints, 3)
+
+	assert.Equal(t, "age", constraints[0].Field)
+	assert.Equal(t, "min", constraints[0].Rule)
+	assert.JSONEq(t, `18`, string(constraints[0].Value))
+	assert.Equal(t, "must be at least 18", constraints[0].Message)
+
+	assert.Equal(t, "max", constraints[1].Rule)
+	assert.Empty(t, constraints[1].Message)
+
+	var pattern string
+	require.NoError(t, json.Unmarshal(constraints[2].Value, &pattern))
+	assert.Equal(t, "^[a-z0-9_]+$", pattern)
+}
+
+func TestConstraintsAbsent(t *testing.T) {
+	for _, body := range []string{
+		`{"success": true}`,
+		`{"success": false, "error": {"code": "ERR", "message": "msg"}}`,
+		`{"success": false, "error": {"code": "ERR", "message": "msg", "constraints": null}}`,
+	} {
+		handler, err := NewHandler([]byte(body))
+		require.NoError(t, err)
+
+		constraints, err := handler.Constraints()
+		require.NoError(t, err)
+		assert.Equal(t, []FieldConstraint{}, constraints, body)
+	}
+
+	var handler *Handler
+	constraints, err := handler.Constraints()
+	require.NoError(t, err)
+	assert.Empty(t, constraints)
+}
+
+func TestConstraintsMalformed(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "msg", "constraints": {"field": "age"}}}`))
+	require.NoError(t, err)
+
+	constraints, err := handler.Constraints()
+	assert.Empty(t, constraints)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
@@ -0,0 +1,171 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MatchesExample compares the data against an example payload by shape:
+// every key in the example must be present with the same JSON type, no
+// unexpected keys may appear, and array elements must match the shape of the
+// example's first element; values are ignored
+// Returns a ValidationError with code CONTRACT_MISMATCH listing the differing
+// paths, which helps catch server contract drift in tests
+func (h *Handler) MatchesExample(example []byte) error {
+	return h.matchesExample(example, false)
+}
+
+// MatchesExampleExact is like MatchesExample but also requires every value
+// and array length to match the example exactly
+func (h *Handler) MatchesExampleExact(example []byte) error {
+	return h.matchesExample(example, true)
+}
+
+// matchesExample implements MatchesExample and MatchesExampleExact
+func (h *Handler) matchesExample(example []byte, exact bool) error {
+	want, err := decodeExample(example)
+	if err != nil {
+		return &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal example",
+			Err:     err,
+		}
+	}
+
+	data := h.GetData()
+	if len(data) == 0 {
+		return &ValidationError{
+			Code:    ErrCodeEmptyData,
+			Message: "response data is empty",
+		}
+	}
+
+	got, err := decodeExample(data)
+	if err != nil {
+		return &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal response data",
+			Err:     err,
+		}
+	}
+
+	var diffs []string
+	compareShape("$", want, got, exact, &diffs)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{
+		Code:    ErrCodeContractMismatch,
+		Message: "data does not match example: " + strings.Join(diffs, "; "),
+		Context: map[string]interface{}{
+			"paths": diffs,
+		},
+	}
+}
+
+// decodeExample decodes JSON keeping numbers as json.Number
+func decodeExample(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jsonTypeName names the JSON type of a decoded value
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+// compareShape appends a description of every difference between want and got
+func compareShape(path string, want, got interface{}, exact bool, diffs *[]string) {
+	wantType, gotType := jsonTypeName(want), jsonTypeName(got)
+	if wantType != gotType {
+		*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, wantType, gotType))
+		return
+	}
+
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g := got.(map[string]interface{})
+		for _, key := range sortedKeys(w) {
+			gv, ok := g[key]
+			if !ok {
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: missing", path, key))
+				continue
+			}
+			compareShape(path+"."+key, w[key], gv, exact, diffs)
+		}
+		for _, key := range sortedKeys(g) {
+			if _, ok := w[key]; !ok {
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: unexpected", path, key))
+			}
+		}
+
+	case []interface{}:
+		g := got.([]interface{})
+		if exact {
+			if len(w) != len(g) {
+				*diffs = append(*diffs, fmt.Sprintf("%s: expected %d elements, got %d", path, len(w), len(g)))
+				return
+			}
+			for i := range w {
+				compareShape(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], exact, diffs)
+			}
+			return
+		}
+		if len(w) == 0 {
+			return
+		}
+		for i := range g {
+			compareShape(fmt.Sprintf("%s[%d]", path, i), w[0], g[i], exact, diffs)
+		}
+
+	default:
+		if exact && !scalarEqual(want, got) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %v, got %v", path, want, got))
+		}
+	}
+}
+
+// scalarEqual reports whether two decoded scalars are equal
+// Numbers are compared exactly by value, so 1 and 1.0 are equal but
+// integers beyond float64 precision are not rounded together
+func scalarEqual(a, b interface{}) bool {
+	an, aok := a.(json.Number)
+	bn, bok := b.(json.Number)
+	if aok && bok {
+		return canonicalNumber(an) == canonicalNumber(bn)
+	}
+	return a == b
+}
+
+// sortedKeys returns the keys of m in sorted order for stable diffs
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
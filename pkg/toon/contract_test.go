@@ -0,0 +1,103 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contractBody = `{
+	"success": true,
+	"data": {
+		"id": 42,
+		"name": "Jane",
+		"active": true,
+		"tags": ["a", "b"],
+		"address": {"city": "Dhaka"}
+	}
+}`
+
+func TestMatchesExampleShape(t *testing.T) {
+	handler, err := NewHandler([]byte(contractBody))
+	require.NoError(t, err)
+
+	example := []byte(`{
+		"id": 1,
+		"name": "example",
+		"active": false,
+		"tags": ["x"],
+		"address": {"city": "Anywhere"}
+	}`)
+	assert.NoError(t, handler.MatchesExample(example))
+}
+
+func TestMatchesExampleShapeMismatch(t *testing.T) {
+	handler, err := NewHandler([]byte(contractBody))
+	require.NoError(t, err)
+
+	example := []byte(`{
+		"id": "1",
+		"name": "example",
+		"email": "e@example.com",
+		"tags": [1],
+		"address": {"city": "Anywhere"}
+	}`)
+
+	err = handler.MatchesExample(example)
+	require.Error(t, err)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeContractMismatch, valErr.Code)
+	assert.ElementsMatch(t, []string{
+		"$.active: unexpected",
+		"$.email: missing",
+		"$.id: expected string, got number",
+		"$.tags[0]: expected number, got string",
+		"$.tags[1]: expected number, got string",
+	}, valErr.Context["paths"])
+}
+
+func TestMatchesExampleExact(t *testing.T) {
+	handler, err := NewHandler([]byte(contractBody))
+	require.NoError(t, err)
+
+	same := []byte(`{"id": 42.0, "name": "Jane", "active": true, "tags": ["a", "b"], "address": {"city": "Dhaka"}}`)
+	assert.NoError(t, handler.MatchesExampleExact(same))
+
+	different := []byte(`{"id": 42, "name": "John", "active": true, "tags": ["a"], "address": {"city": "Dhaka"}}`)
+	err = handler.MatchesExampleExact(different)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.ElementsMatch(t, []string{
+		"$.name: expected John, got Jane",
+		"$.tags: expected 1 elements, got 2",
+	}, valErr.Context["paths"])
+}
+
+func TestMatchesExampleExactLargeIntegers(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 9007199254740993}}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, handler.MatchesExampleExact([]byte(`{"id": 9007199254740993}`)))
+	assert.NoError(t, handler.MatchesExampleExact([]byte(`{"id": 9.007199254740993e15}`)))
+
+	err = handler.MatchesExampleExact([]byte(`{"id": 9007199254740992}`))
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, []string{"$.id: expected 9007199254740992, got 9007199254740993"}, valErr.Context["paths"])
+}
+
+func TestMatchesExampleInvalidInput(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, handler.MatchesExample([]byte(`{}`)), &valErr)
+	assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+
+	require.ErrorAs(t, handler.MatchesExample([]byte(`{bad`)), &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
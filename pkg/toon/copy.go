@@ -0,0 +1,99 @@
+package toon
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// ResponseCopy returns a deep copy of the parsed response that callers may
+// freely mutate without affecting the handler
+// Use Response for zero-copy reads
+// Returns nil if the handler or response is nil
+func (h *Handler) ResponseCopy() *Response {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil {
+		return nil
+	}
+
+	c := &Response{
+		Success: h.resp.Success,
+		Data:    cloneRaw(h.resp.Data),
+		Error:   h.resp.Error.clone(),
+		Meta:    h.resp.Meta.clone(),
+	}
+	if h.resp.Errors != nil {
+		c.Errors = make([]*ResponseError, len(h.resp.Errors))
+		for i, e := range h.resp.Errors {
+			c.Errors[i] = e.clone()
+		}
+	}
+	if h.resp.Results != nil {
+		c.Results = make([]json.RawMessage, len(h.resp.Results))
+		for i, r := range h.resp.Results {
+			c.Results[i] = cloneRaw(r)
+		}
+	}
+	return c
+}
+
+// clone returns a deep copy of the error, including its Extra values
+func (e *ResponseError) clone() *ResponseError {
+	if e == nil {
+		return nil
+	}
+
+	c := *e
+	c.Cause = e.Cause.clone()
+	if e.Extra != nil {
+		c.Extra = make(map[string]json.RawMessage, len(e.Extra))
+		for k, v := range e.Extra {
+			c.Extra[k] = cloneRaw(v)
+		}
+	}
+	return &c
+}
+
+// clone returns a deep copy of the metadata
+func (m *Meta) clone() *Meta {
+	if m == nil {
+		return nil
+	}
+
+	c := *m
+	if m.RateLimit != nil {
+		rl := *m.RateLimit
+		c.RateLimit = &rl
+	}
+	c.RateLimits = maps.Clone(m.RateLimits)
+	if m.Warnings != nil {
+		c.Warnings = append([]string(nil), m.Warnings...)
+	}
+	if m.Sunset != nil {
+		sunset := *m.Sunset
+		c.Sunset = &sunset
+	}
+	if m.Pagination != nil {
+		p := *m.Pagination
+		c.Pagination = &p
+	}
+	if m.Extra != nil {
+		c.Extra = make(map[string]json.RawMessage, len(m.Extra))
+		for k, v := range m.Extra {
+			c.Extra[k] = cloneRaw(v)
+		}
+	}
+	return &c
+}
+
+// cloneRaw returns a copy of raw JSON, keeping nil as nil
+func cloneRaw(raw json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return nil
+	}
+	return append(json.RawMessage{}, raw...)
+}
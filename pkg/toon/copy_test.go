@@ -0,0 +1,80 @@
+package toon
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const copyBody = `{
+	"success": false,
+	"data": {"id": 1},
+	"error": {"code": "INVALID", "message": "bad input", "hint": "check id"},
+	"errors": [{"code": "REQUIRED", "message": "name is required", "field": "name"}],
+	"meta": {
+		"request_id": "req-1",
+		"rate_limit": {"limit": 10, "remaining": 5, "reset": "2025-12-31T23:59:59Z"},
+		"rate_limits": {"writes": {"limit": 5, "remaining": 1}},
+		"warnings": ["deprecated"],
+		"sunset": "2026-01-01T00:00:00Z",
+		"pagination": {"next_cursor": "abc", "has_more": true}
+	}
+}`
+
+func TestResponseCopyIsIndependent(t *testing.T) {
+	handler, err := NewHandler([]byte(copyBody))
+	require.NoError(t, err)
+
+	before, err := json.Marshal(handler.Response())
+	require.NoError(t, err)
+
+	c := handler.ResponseCopy()
+	require.NotNil(t, c)
+	assert.Equal(t, handler.Response(), c)
+
+	c.Success = true
+	c.Data[2] = 'X'
+	c.Error.Code = "MUTATED"
+	c.Error.Extra["hint"][1] = 'X'
+	c.Errors[0].Field = "mutated"
+	c.Meta.RequestID = "mutated"
+	c.Meta.RateLimit.Remaining = 0
+	c.Meta.RateLimits["writes"] = RateLimit{Remaining: 99}
+	c.Meta.Warnings[0] = "mutated"
+	*c.Meta.Sunset = time.Time{}
+	c.Meta.Pagination.NextCursor = "mutated"
+
+	after, err := json.Marshal(handler.Response())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after))
+	assert.Equal(t, "req-1", handler.GetRequestID())
+	assert.Equal(t, 5, handler.GetRateLimit().Remaining)
+}
+
+func TestResponseCopyNil(t *testing.T) {
+	var handler *Handler
+	assert.Nil(t, handler.ResponseCopy())
+
+	minimal, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	c := minimal.ResponseCopy()
+	require.NotNil(t, c)
+	assert.True(t, c.Success)
+	assert.Nil(t, c.Data)
+	assert.Nil(t, c.Error)
+	assert.Nil(t, c.Meta)
+}
+
+func TestResponseCopyClonesCause(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "A", "message": "a", "cause": {"code": "B", "message": "b"}}}`))
+	require.NoError(t, err)
+
+	c := handler.ResponseCopy()
+	require.NotNil(t, c.Error.Cause)
+	c.Error.Cause.Code = "changed"
+	assert.Equal(t, "B", handler.GetError().Cause.Code)
+}
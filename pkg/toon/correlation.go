@@ -0,0 +1,31 @@
+package toon
+
+import "net/http"
+
+// DefaultRequestIDHeader is the header used by PropagateRequestID
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// PropagateRequestID sets the X-Request-ID header on an outbound request
+// from the handler's request ID, enabling correlation across a call chain
+func (h *Handler) PropagateRequestID(req *http.Request) {
+	h.PropagateRequestIDHeader(req, DefaultRequestIDHeader)
+}
+
+// PropagateRequestIDHeader sets the named header on an outbound request
+// from the handler's request ID
+// The request is left unchanged if no request ID is available
+func (h *Handler) PropagateRequestIDHeader(req *http.Request, header string) {
+	if h == nil || req == nil || header == "" {
+		return
+	}
+
+	requestID := h.GetRequestID()
+	if requestID == "" {
+		return
+	}
+
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	req.Header.Set(header, requestID)
+}
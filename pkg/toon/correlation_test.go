@@ -0,0 +1,45 @@
+package toon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagateRequestID(t *testing.T) {
+	body := []byte(`{"success": true, "meta": {"request_id": "req-123"}}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+
+	handler.PropagateRequestID(req)
+	assert.Equal(t, "req-123", req.Header.Get(DefaultRequestIDHeader))
+}
+
+func TestPropagateRequestIDCustomHeader(t *testing.T) {
+	body := []byte(`{"success": true, "meta": {"request_id": "req-123"}}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+
+	handler.PropagateRequestIDHeader(req, "X-Correlation-ID")
+	assert.Equal(t, "req-123", req.Header.Get("X-Correlation-ID"))
+	assert.Empty(t, req.Header.Get(DefaultRequestIDHeader))
+}
+
+func TestPropagateRequestIDWithoutRequestID(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+
+	handler.PropagateRequestID(req)
+	assert.Empty(t, req.Header)
+}
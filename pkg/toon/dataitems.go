@@ -0,0 +1,91 @@
+package toon
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// StreamDataItems decodes an array data payload element by element, sending
+// each raw element on the returned item channel so that consumers can process
+// large arrays without decoding them into one value
+// The item channel is closed when the array ends or decoding stops; at most
+// one error is then sent on the error channel before it is closed as well
+// Errors are ValidationErrors: EMPTY_DATA without data, JSON_UNMARSHAL when
+// the data is not an array or an element is malformed, and REQUEST_CANCELED
+// when ctx is done before every element was received
+// The decoding goroutine blocks until each item is received, so callers must
+// either drain the item channel or cancel ctx; abandoning the channel without
+// canceling leaks the goroutine
+func (h *Handler) StreamDataItems(ctx context.Context) (<-chan json.RawMessage, <-chan error) {
+	items := make(chan json.RawMessage)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(items)
+
+		if err := h.streamDataItems(ctx, items); err != nil {
+			errs <- err
+		}
+	}()
+	return items, errs
+}
+
+// streamDataItems implements StreamDataItems
+func (h *Handler) streamDataItems(ctx context.Context, items chan<- json.RawMessage) error {
+	if h == nil {
+		return &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	dec, err := h.DataDecoder()
+	if err != nil {
+		return err
+	}
+	dataSize := h.DataLen()
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "response data is not an array",
+			Err:     err,
+			Context: map[string]interface{}{
+				"data_size": dataSize,
+			},
+		}
+	}
+
+	for index := 0; dec.More(); index++ {
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			valErr := decodeError(err, dataSize, &item)
+			valErr.Context["index"] = index
+			return valErr
+		}
+
+		// Check first as select picks randomly when both cases are ready
+		if ctx.Err() != nil {
+			return streamCanceled(ctx, index)
+		}
+		select {
+		case items <- item:
+		case <-ctx.Done():
+			return streamCanceled(ctx, index)
+		}
+	}
+	return nil
+}
+
+// streamCanceled builds the error for a stream stopped by ctx at index
+func streamCanceled(ctx context.Context, index int) *ValidationError {
+	return &ValidationError{
+		Code:    ErrCodeRequestCanceled,
+		Message: "canceled while streaming data items",
+		Err:     ctx.Err(),
+		Context: map[string]interface{}{
+			"index": index,
+		},
+	}
+}
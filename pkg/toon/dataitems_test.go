@@ -0,0 +1,101 @@
+package toon
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectItems drains the channels returned by StreamDataItems
+func collectItems(items <-chan json.RawMessage, errs <-chan error) ([]string, error) {
+	var got []string
+	for item := range items {
+		got = append(got, string(item))
+	}
+	return got, <-errs
+}
+
+func TestStreamDataItems(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": [1, "two", {"id": 3}, [4], null]}`))
+	require.NoError(t, err)
+
+	got, err := collectItems(handler.StreamDataItems(context.Background()))
+	require.NoError(t, err)
+	assert.Equal(t, []string{`1`, `"two"`, `{"id": 3}`, `[4]`, `null`}, got)
+}
+
+func TestStreamDataItemsEmptyArray(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": []}`))
+	require.NoError(t, err)
+
+	got, err := collectItems(handler.StreamDataItems(context.Background()))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestStreamDataItemsMalformedElement(t *testing.T) {
+	handler := &Handler{resp: &Response{Success: true, Data: json.RawMessage(`[1, {"id": 2}, {bad}, 4]`)}}
+
+	got, err := collectItems(handler.StreamDataItems(context.Background()))
+	assert.Equal(t, []string{`1`, `{"id": 2}`}, got)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Equal(t, DecodeErrorSyntax, valErr.Kind)
+	assert.Equal(t, 2, valErr.Context["index"])
+}
+
+func TestStreamDataItemsNotArray(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code ErrCode
+	}{
+		{"object", `{"success": true, "data": {"id": 1}}`, ErrCodeJSONUnmarshal},
+		{"no data", `{"success": true}`, ErrCodeEmptyData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+
+			got, err := collectItems(handler.StreamDataItems(context.Background()))
+			assert.Empty(t, got)
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, tt.code, valErr.Code)
+		})
+	}
+
+	var nilHandler *Handler
+	_, err := collectItems(nilHandler.StreamDataItems(context.Background()))
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+}
+
+func TestStreamDataItemsCanceled(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": [1, 2, 3]}`))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items, errs := handler.StreamDataItems(ctx)
+
+	assert.Equal(t, `1`, string(<-items))
+	cancel()
+
+	// The producer may deliver at most one more element before it sees ctx
+	for range items {
+	}
+
+	var valErr *ValidationError
+	require.ErrorAs(t, <-errs, &valErr)
+	assert.Equal(t, ErrCodeRequestCanceled, valErr.Code)
+	assert.ErrorIs(t, valErr, context.Canceled)
+}
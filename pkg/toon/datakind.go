@@ -0,0 +1,83 @@
+package toon
+
+// DataKind describes the JSON kind of the response data
+type DataKind int
+
+const (
+	DataKindEmpty DataKind = iota
+	DataKindObject
+	DataKindArray
+	DataKindString
+	DataKindNumber
+	DataKindBool
+	DataKindNull
+)
+
+// String returns the lowercase name of the kind
+func (k DataKind) String() string {
+	switch k {
+	case DataKindObject:
+		return "object"
+	case DataKindArray:
+		return "array"
+	case DataKindString:
+		return "string"
+	case DataKindNumber:
+		return "number"
+	case DataKindBool:
+		return "bool"
+	case DataKindNull:
+		return "null"
+	default:
+		return "empty"
+	}
+}
+
+// DataKind returns the JSON kind of the response data by inspecting its
+// first non-whitespace byte, avoiding a full decode just to branch
+// Returns DataKindEmpty if no data is present
+func (h *Handler) DataKind() DataKind {
+	if h == nil {
+		return DataKindEmpty
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil {
+		return DataKindEmpty
+	}
+	return dataKindOf(h.resp.Data)
+}
+
+// dataKindOf classifies raw JSON by its first non-whitespace byte
+func dataKindOf(data []byte) DataKind {
+	for _, c := range data {
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '{':
+			return DataKindObject
+		case '[':
+			return DataKindArray
+		case '"':
+			return DataKindString
+		case 't', 'f':
+			return DataKindBool
+		case 'n':
+			return DataKindNull
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return DataKindNumber
+		default:
+			return DataKindEmpty
+		}
+	}
+	return DataKindEmpty
+}
+
+// DataIsNull reports whether the response carried an explicit "data": null,
+// as opposed to omitting the data field; some APIs use null to mean a value
+// was cleared rather than not provided
+func (h *Handler) DataIsNull() bool {
+	return h.DataKind() == DataKindNull
+}
@@ -0,0 +1,63 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected DataKind
+	}{
+		{name: "object", body: `{"success": true, "data": {"id": 1}}`, expected: DataKindObject},
+		{name: "array", body: `{"success": true, "data": [1, 2]}`, expected: DataKindArray},
+		{name: "string", body: `{"success": true, "data": "text"}`, expected: DataKindString},
+		{name: "number", body: `{"success": true, "data": -12.5}`, expected: DataKindNumber},
+		{name: "bool", body: `{"success": true, "data": false}`, expected: DataKindBool},
+		{name: "null", body: `{"success": true, "data": null}`, expected: DataKindNull},
+		{name: "empty", body: `{"success": true}`, expected: DataKindEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, handler.DataKind())
+			assert.Equal(t, tt.name, handler.DataKind().String())
+		})
+	}
+}
+
+func TestDataKindLeadingWhitespace(t *testing.T) {
+	assert.Equal(t, DataKindArray, dataKindOf([]byte(" \n\t [1]")))
+	assert.Equal(t, DataKindObject, dataKindOf([]byte("\r\n{}")))
+	assert.Equal(t, DataKindEmpty, dataKindOf([]byte("   ")))
+}
+
+func TestDataIsNull(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"omitted", `{"success": true}`, false},
+		{"null", `{"success": true, "data": null}`, true},
+		{"present", `{"success": true, "data": {"id": 1}}`, false},
+		{"null string", `{"success": true, "data": "null"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, handler.DataIsNull())
+		})
+	}
+
+	var handler *Handler
+	assert.False(t, handler.DataIsNull())
+}
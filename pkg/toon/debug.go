@@ -0,0 +1,125 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Pretty returns a multi-line, human-readable rendering of the response
+// It includes the success flag, formatted error, meta fields and indented data
+// It is meant for debug logging and CLI output; use String for a terse form
+func (h *Handler) Pretty() string {
+	if h == nil || h.Response() == nil {
+		return "Handler(nil)"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Success: %v\n", h.IsSuccess())
+
+	if errStr := h.ErrorString(); errStr != "" {
+		fmt.Fprintf(&b, "Error: %s\n", errStr)
+	}
+
+	if meta := h.GetMeta(); meta != nil {
+		b.WriteString("Meta:\n")
+		if meta.RequestID != "" {
+			fmt.Fprintf(&b, "    Request ID: %s\n", meta.RequestID)
+		}
+		if meta.APIVersion != "" {
+			fmt.Fprintf(&b, "    API Version: %s\n", meta.APIVersion)
+		}
+		if !meta.Timestamp.IsZero() {
+			fmt.Fprintf(&b, "    Timestamp: %s\n", meta.Timestamp.Format(time.RFC3339))
+		}
+		if meta.RateLimit != nil {
+			fmt.Fprintf(&b, "    Rate Limit: %s\n", h.GetRateLimitStatus())
+		}
+		for _, warning := range meta.Warnings {
+			fmt.Fprintf(&b, "    Warning: %s\n", warning)
+		}
+		if meta.Sunset != nil && !meta.Sunset.IsZero() {
+			fmt.Fprintf(&b, "    Sunset: %s\n", meta.Sunset.Format(time.RFC3339))
+		}
+	}
+
+	if data := h.GetData(); len(data) > 0 {
+		b.WriteString("Data:\n")
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "    ", "    "); err != nil {
+			indented.Reset()
+			indented.Write(data)
+		}
+		fmt.Fprintf(&b, "    %s\n", indented.String())
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// DefaultRedactedKeys are the keys redacted by DumpRedacted when none are given
+var DefaultRedactedKeys = []string{"password", "token", "secret"}
+
+// redactedValue replaces the values of redacted keys
+const redactedValue = "[REDACTED]"
+
+// DumpRedacted returns the indented data with the values of the given keys
+// replaced by "[REDACTED]" at any depth; keys match case-insensitively
+// When no keys are given, DefaultRedactedKeys is used
+// It operates on a decoded copy so the handler is left untouched
+func (h *Handler) DumpRedacted(keys ...string) (string, error) {
+	dec, err := h.DataDecoder(UseNumber())
+	if err != nil {
+		return "", err
+	}
+
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return "", &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal data for redaction",
+			Err:     err,
+		}
+	}
+
+	if len(keys) == 0 {
+		keys = DefaultRedactedKeys
+	}
+	redact := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		redact[strings.ToLower(key)] = struct{}{}
+	}
+
+	out, err := json.MarshalIndent(redactValue(data, redact), "", "    ")
+	if err != nil {
+		return "", &ValidationError{
+			Code:    ErrCodeJSONMarshal,
+			Message: "failed to marshal redacted data",
+			Err:     err,
+		}
+	}
+	return string(out), nil
+}
+
+// redactValue recursively replaces the values of redacted keys in decoded JSON
+func redactValue(v interface{}, redact map[string]struct{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			if _, ok := redact[strings.ToLower(key)]; ok {
+				val[key] = redactedValue
+				continue
+			}
+			val[key] = redactValue(child, redact)
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child, redact)
+		}
+		return val
+	default:
+		return v
+	}
+}
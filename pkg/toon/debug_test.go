@@ -0,0 +1,74 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPretty(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"data": {"user": {"id": 1}},
+		"meta": {"request_id": "req-123", "api_version": "v1"}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	out := handler.Pretty()
+	assert.Contains(t, out, "Success: true")
+	assert.Contains(t, out, "Request ID: req-123")
+	assert.Contains(t, out, "API Version: v1")
+	assert.Contains(t, out, "Data:\n    {\n        \"user\": {\n            \"id\": 1\n        }\n    }")
+}
+
+func TestPrettyError(t *testing.T) {
+	body := []byte(`{"success": false, "error": {"code": "ERR", "message": "msg"}}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	out := handler.Pretty()
+	assert.Contains(t, out, "Success: false")
+	assert.Contains(t, out, "Error: ERR | msg")
+	assert.NotContains(t, out, "Data:")
+}
+
+func TestDumpRedacted(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"data": {
+			"user": {"name": "test", "Password": "hunter2", "sessions": [{"token": "abc", "id": 1}]},
+			"api_key": "key-123"
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	out, err := handler.DumpRedacted()
+	require.NoError(t, err)
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "abc")
+	assert.Contains(t, out, `"Password": "[REDACTED]"`)
+	assert.Contains(t, out, `"token": "[REDACTED]"`)
+	assert.Contains(t, out, "key-123")
+	assert.Contains(t, string(handler.GetData()), "hunter2")
+
+	out, err = handler.DumpRedacted("api_key")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "key-123")
+	assert.Contains(t, out, "hunter2")
+}
+
+func TestDumpRedactedWithoutData(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	_, err = handler.DumpRedacted()
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+}
@@ -0,0 +1,219 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DecoderOption configures a json.Decoder created over the response data
+type DecoderOption func(*json.Decoder)
+
+// UseNumber makes the decoder decode numbers into json.Number instead of float64
+func UseNumber() DecoderOption {
+	return func(dec *json.Decoder) {
+		dec.UseNumber()
+	}
+}
+
+// DisallowUnknownFields makes the decoder reject object keys with no matching struct field
+func DisallowUnknownFields() DecoderOption {
+	return func(dec *json.Decoder) {
+		dec.DisallowUnknownFields()
+	}
+}
+
+// DataDecoder returns a json.Decoder positioned at the start of the data value
+// The decoder reads from a copy of the data, with any transforms registered
+// with WithDataTransform applied, so it is safe to use concurrently with
+// other handler methods
+// Returns ValidationError if data is empty or a transform fails
+func (h *Handler) DataDecoder(opts ...DecoderOption) (*json.Decoder, error) {
+	data, err := h.decodableData()
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for _, opt := range opts {
+		if opt != nil {
+			opt(dec)
+		}
+	}
+	return dec, nil
+}
+
+// UnmarshalDataNumber unmarshals the response data like UnmarshalData but
+// decodes numbers into json.Number when the target is an interface{}
+// This preserves the precision of 64-bit integer IDs
+func (h *Handler) UnmarshalDataNumber(v interface{}) error {
+	if v == nil {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "target interface is nil",
+		}
+	}
+
+	dec, err := h.DataDecoder(UseNumber())
+	if err != nil {
+		return err
+	}
+
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err, len(h.GetData()), v)
+	}
+
+	return nil
+}
+
+// UnmarshalDataStrict unmarshals the response data like UnmarshalData but
+// rejects object keys with no matching struct field, reporting them as
+// DecodeErrorUnknownField; it catches server contract drift early
+func (h *Handler) UnmarshalDataStrict(v interface{}) error {
+	if v == nil {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "target interface is nil",
+		}
+	}
+
+	dec, err := h.DataDecoder(DisallowUnknownFields())
+	if err != nil {
+		return err
+	}
+
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err, len(h.GetData()), v)
+	}
+	return nil
+}
+
+// UnmarshalDataSlice unmarshals an array data payload into the slice pointed to by v
+// and returns the number of decoded elements
+// The slice is preallocated from a quick scan of the raw array to reduce reallocations
+// Returns ValidationError if v is not a pointer to a slice or data is not an array
+func (h *Handler) UnmarshalDataSlice(v interface{}) (int, error) {
+	rv := reflect.ValueOf(v)
+	if v == nil || rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return 0, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "target must be a non-nil pointer to a slice",
+			Context: map[string]interface{}{
+				"target": fmt.Sprintf("%T", v),
+			},
+		}
+	}
+
+	data, err := h.decodableData()
+	if err != nil {
+		return 0, err
+	}
+
+	count, ok := countArrayElements(data)
+	if !ok {
+		return 0, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "response data is not an array",
+			Context: map[string]interface{}{
+				"data_size": len(data),
+				"target":    fmt.Sprintf("%T", v),
+			},
+		}
+	}
+
+	slice := rv.Elem()
+	if slice.Cap() < count {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, count))
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return 0, decodeError(err, len(data), v)
+	}
+
+	return rv.Elem().Len(), nil
+}
+
+// countArrayElements counts the top-level elements of a JSON array without decoding it
+// It returns false if data is not an array
+func countArrayElements(data []byte) (int, bool) {
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '[' {
+		return 0, false
+	}
+
+	count := 0
+	depth := 0
+	inString := false
+	escaped := false
+	hasElement := false
+
+	for _, c := range data[1:] {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '"':
+			inString = true
+		case '[', '{':
+			depth++
+		case ']', '}':
+			if depth == 0 {
+				if hasElement {
+					count++
+				}
+				return count, true
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				count++
+				continue
+			}
+		}
+		hasElement = true
+	}
+
+	return count, true
+}
+
+// UnmarshalDataOrDefault unmarshals the response data into v, or defaultJSON
+// when the data is absent or null
+// This centralizes the "no data means empty list" pattern for success responses
+func (h *Handler) UnmarshalDataOrDefault(v interface{}, defaultJSON []byte) error {
+	data := bytes.TrimSpace(h.GetData())
+	if len(data) > 0 && !bytes.Equal(data, []byte("null")) {
+		return h.UnmarshalData(v)
+	}
+
+	if v == nil {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "target interface is nil",
+		}
+	}
+
+	if err := json.Unmarshal(defaultJSON, v); err != nil {
+		return &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal default data into target type",
+			Err:     err,
+			Context: map[string]interface{}{
+				"data_size": len(defaultJSON),
+				"target":    fmt.Sprintf("%T", v),
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataDecoderWalksArray(t *testing.T) {
+	body := []byte(`{"success": true, "data": [{"id": 1}, {"id": 2}, {"id": 3}]}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	dec, err := handler.DataDecoder(UseNumber())
+	require.NoError(t, err)
+
+	tok, err := dec.Token()
+	require.NoError(t, err)
+	assert.Equal(t, json.Delim('['), tok)
+
+	var ids []json.Number
+	for dec.More() {
+		var item struct {
+			ID json.Number `json:"id"`
+		}
+		require.NoError(t, dec.Decode(&item))
+		ids = append(ids, item.ID)
+	}
+
+	tok, err = dec.Token()
+	require.NoError(t, err)
+	assert.Equal(t, json.Delim(']'), tok)
+	assert.Equal(t, []json.Number{"1", "2", "3"}, ids)
+}
+
+func TestDataDecoderWithEmptyData(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	dec, err := handler.DataDecoder()
+	assert.Nil(t, dec)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+}
+
+func TestUnmarshalDataNumberPreservesLargeIntegers(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"id": 9007199254740993, "name": "snowflake"}}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var data map[string]interface{}
+	require.NoError(t, handler.UnmarshalDataNumber(&data))
+
+	id, ok := data["id"].(json.Number)
+	require.True(t, ok)
+	assert.Equal(t, "9007199254740993", id.String())
+
+	n, err := id.Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), n)
+}
+
+func TestUnmarshalDataNumberErrors(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, handler.UnmarshalDataNumber(nil), &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+
+	var target []string
+	assert.ErrorAs(t, handler.UnmarshalDataNumber(&target), &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestUnmarshalDataSlice(t *testing.T) {
+	body := []byte(`{"success": true, "data": [{"id": 1, "tags": ["a,b", "]"]}, {"id": 2}, {"id": 3}]}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var items []struct {
+		ID int `json:"id"`
+	}
+	count, err := handler.UnmarshalDataSlice(&items)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Len(t, items, 3)
+	assert.Equal(t, 3, items[2].ID)
+}
+
+func TestUnmarshalDataSliceErrors(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	var valErr *ValidationError
+	var items []int
+	_, err = handler.UnmarshalDataSlice(&items)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+
+	_, err = handler.UnmarshalDataSlice(items)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+}
+
+func TestCountArrayElements(t *testing.T) {
+	tests := []struct {
+		data  string
+		count int
+		ok    bool
+	}{
+		{data: `[]`, count: 0, ok: true},
+		{data: ` [ ] `, count: 0, ok: true},
+		{data: `[1]`, count: 1, ok: true},
+		{data: `[1, [2, 3], {"a": [4]}]`, count: 3, ok: true},
+		{data: `["a\"]", "b"]`, count: 2, ok: true},
+		{data: `{"a": 1}`, count: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		count, ok := countArrayElements([]byte(tt.data))
+		assert.Equal(t, tt.ok, ok, tt.data)
+		assert.Equal(t, tt.count, count, tt.data)
+	}
+}
+
+// largeArrayBody returns a success body whose data is an array of n objects
+func largeArrayBody(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"success": true, "data": [`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"id": %d, "name": "item-%d"}`, i, i)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
+
+type benchItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func BenchmarkUnmarshalDataLargeArray(b *testing.B) {
+	handler, _ := NewHandler(largeArrayBody(10000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var items []benchItem
+		_ = handler.UnmarshalData(&items)
+	}
+}
+
+func BenchmarkUnmarshalDataSliceLargeArray(b *testing.B) {
+	handler, _ := NewHandler(largeArrayBody(10000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var items []benchItem
+		_, _ = handler.UnmarshalDataSlice(&items)
+	}
+}
+
+func TestUnmarshalDataOrDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []int
+	}{
+		{name: "present data", body: `{"success": true, "data": [1, 2]}`, expected: []int{1, 2}},
+		{name: "absent data", body: `{"success": true}`, expected: []int{}},
+		{name: "null data", body: `{"success": true, "data": null}`, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+
+			var ids []int
+			require.NoError(t, handler.UnmarshalDataOrDefault(&ids, []byte(`[]`)))
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestUnmarshalDataOrDefaultInvalidDefault(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	var ids []int
+	err = handler.UnmarshalDataOrDefault(&ids, []byte(`{`))
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestUnmarshalDataErrorKinds(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	t.Run("type mismatch", func(t *testing.T) {
+		handler, err := NewHandler([]byte(`{"success": true, "data": {"id": "not-a-number"}}`))
+		require.NoError(t, err)
+
+		var u user
+		err = handler.UnmarshalData(&u)
+
+		var valErr *ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+		assert.Equal(t, DecodeErrorTypeMismatch, valErr.Kind)
+		assert.Equal(t, "type_mismatch", valErr.Context["kind"])
+		assert.Equal(t, "id", valErr.Context["field"])
+		assert.Equal(t, "string", valErr.Context["json_type"])
+		assert.Equal(t, "int", valErr.Context["go_type"])
+	})
+
+	t.Run("syntax", func(t *testing.T) {
+		broken := func(json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(`{"id": 1,`), nil
+		}
+		handler, err := NewHandlerWithOptions([]byte(`{"success": true, "data": {"id": 1}}`), WithDataTransform(broken))
+		require.NoError(t, err)
+
+		var u user
+		err = handler.UnmarshalData(&u)
+
+		var valErr *ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, DecodeErrorSyntax, valErr.Kind)
+		assert.Equal(t, "syntax", valErr.Context["kind"])
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1, "email": "a@b.c"}}`))
+		require.NoError(t, err)
+
+		var u user
+		require.NoError(t, handler.UnmarshalData(&u))
+
+		err = handler.UnmarshalDataStrict(&u)
+
+		var valErr *ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, DecodeErrorUnknownField, valErr.Kind)
+		assert.Equal(t, "email", valErr.Context["field"])
+	})
+
+	t.Run("not a decode error", func(t *testing.T) {
+		handler, err := NewHandler([]byte(`{"success": true}`))
+		require.NoError(t, err)
+
+		var u user
+		err = handler.UnmarshalData(&u)
+
+		var valErr *ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+		assert.Equal(t, DecodeErrorNone, valErr.Kind)
+	})
+}
+
+func TestDecodeErrorKindString(t *testing.T) {
+	assert.Equal(t, "none", DecodeErrorNone.String())
+	assert.Equal(t, "syntax", DecodeErrorSyntax.String())
+	assert.Equal(t, "type_mismatch", DecodeErrorTypeMismatch.String())
+	assert.Equal(t, "unknown_field", DecodeErrorUnknownField.String())
+	assert.Equal(t, "other", DecodeErrorOther.String())
+}
@@ -1,27 +1,80 @@
 package toon
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // ErrCode represents standardized error codes
 type ErrCode string
 
 const (
-	ErrCodeInvalidResponse    ErrCode = "INVALID_RESPONSE"
-	ErrCodeEmptyResponse      ErrCode = "EMPTY_RESPONSE"
-	ErrCodeJSONUnmarshal      ErrCode = "JSON_UNMARSHAL"
-	ErrCodeNilHandler         ErrCode = "NIL_HANDLER"
-	ErrCodeNilResponse        ErrCode = "NIL_RESPONSE"
-	ErrCodeEmptyData          ErrCode = "EMPTY_DATA"
-	ErrCodeIORead             ErrCode = "IO_READ"
-	ErrCodeInvalidStatusCode  ErrCode = "INVALID_STATUS_CODE"
+	ErrCodeInvalidResponse   ErrCode = "INVALID_RESPONSE"
+	ErrCodeEmptyResponse     ErrCode = "EMPTY_RESPONSE"
+	ErrCodeJSONUnmarshal     ErrCode = "JSON_UNMARSHAL"
+	ErrCodeJSONMarshal       ErrCode = "JSON_MARSHAL"
+	ErrCodeNilHandler        ErrCode = "NIL_HANDLER"
+	ErrCodeNilResponse       ErrCode = "NIL_RESPONSE"
+	ErrCodeEmptyData         ErrCode = "EMPTY_DATA"
+	ErrCodeIORead            ErrCode = "IO_READ"
+	ErrCodeInvalidStatusCode ErrCode = "INVALID_STATUS_CODE"
+	ErrCodeRequestFailed     ErrCode = "REQUEST_FAILED"
+	ErrCodeFileRead          ErrCode = "FILE_READ"
+	ErrCodeTruncatedBody     ErrCode = "TRUNCATED_BODY"
+	ErrCodeRequestCanceled   ErrCode = "REQUEST_CANCELED"
+	ErrCodeMissingRequestID  ErrCode = "MISSING_REQUEST_ID"
+	ErrCodeDataTransform     ErrCode = "DATA_TRANSFORM"
+	ErrCodeValidatorFailed   ErrCode = "VALIDATOR_FAILED"
+	ErrCodeContractMismatch  ErrCode = "CONTRACT_MISMATCH"
+	ErrCodeProxyError        ErrCode = "PROXY_ERROR"
+	ErrCodeReadTimeout       ErrCode = "READ_TIMEOUT"
 )
 
+// DecodeErrorKind classifies why response data failed to decode into a target
+type DecodeErrorKind int
+
+const (
+	// DecodeErrorNone is the kind of errors that are not data decode failures
+	DecodeErrorNone DecodeErrorKind = iota
+	// DecodeErrorSyntax means the data is not valid JSON
+	DecodeErrorSyntax
+	// DecodeErrorTypeMismatch means a JSON value does not fit the target's Go type
+	DecodeErrorTypeMismatch
+	// DecodeErrorUnknownField means an object key has no matching struct field
+	// It only occurs when unknown fields are disallowed
+	DecodeErrorUnknownField
+	// DecodeErrorOther covers any other decode failure
+	DecodeErrorOther
+)
+
+// String returns the snake_case name of the kind
+func (k DecodeErrorKind) String() string {
+	switch k {
+	case DecodeErrorSyntax:
+		return "syntax"
+	case DecodeErrorTypeMismatch:
+		return "type_mismatch"
+	case DecodeErrorUnknownField:
+		return "unknown_field"
+	case DecodeErrorOther:
+		return "other"
+	default:
+		return "none"
+	}
+}
+
 // ValidationError represents a validation error with context
 type ValidationError struct {
 	Code    ErrCode
 	Message string
 	Err     error
 	Context map[string]interface{}
+
+	// Kind classifies JSON_UNMARSHAL errors from decoding data into a target
+	Kind DecodeErrorKind
 }
 
 // Error implements the error interface for ValidationError
@@ -42,3 +95,79 @@ func (ve *ValidationError) Unwrap() error {
 	}
 	return ve.Err
 }
+
+// clone returns a copy of the error with its own Context map
+// Errors stored on a handler are cloned before being returned so that
+// concurrent callers never share a mutable map
+func (ve *ValidationError) clone() *ValidationError {
+	if ve == nil {
+		return nil
+	}
+
+	c := *ve
+	if ve.Context != nil {
+		c.Context = make(map[string]interface{}, len(ve.Context))
+		for k, v := range ve.Context {
+			c.Context[k] = v
+		}
+	}
+	return &c
+}
+
+// withContext merges the given context into the ValidationError found in err's chain
+// The original code, message and cause are preserved; existing keys are not overwritten
+func withContext(err error, ctx map[string]interface{}) error {
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve == nil {
+		return err
+	}
+
+	merged := make(map[string]interface{}, len(ve.Context)+len(ctx))
+	for k, v := range ctx {
+		merged[k] = v
+	}
+	for k, v := range ve.Context {
+		merged[k] = v
+	}
+	ve.Context = merged
+	return err
+}
+
+// decodeError builds the JSON_UNMARSHAL error for data that failed to decode
+// into v, classifying the failure and recording where it happened
+func decodeError(err error, dataSize int, v interface{}) *ValidationError {
+	ve := &ValidationError{
+		Code:    ErrCodeJSONUnmarshal,
+		Message: "failed to unmarshal data into target type",
+		Err:     err,
+		Context: map[string]interface{}{
+			"data_size": dataSize,
+			"target":    fmt.Sprintf("%T", v),
+		},
+		Kind: DecodeErrorOther,
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		ve.Kind = DecodeErrorSyntax
+		ve.Context["offset"] = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		ve.Kind = DecodeErrorTypeMismatch
+		ve.Context["offset"] = typeErr.Offset
+		ve.Context["json_type"] = typeErr.Value
+		ve.Context["go_type"] = typeErr.Type.String()
+		if typeErr.Field != "" {
+			ve.Context["field"] = typeErr.Field
+		}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json reports unknown fields with an unexported error type
+		ve.Kind = DecodeErrorUnknownField
+		if field, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field ")); unquoteErr == nil {
+			ve.Context["field"] = field
+		}
+	}
+	ve.Context["kind"] = ve.Kind.String()
+	return ve
+}
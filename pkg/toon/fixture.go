@@ -0,0 +1,59 @@
+package toon
+
+import (
+	"io/fs"
+	"os"
+)
+
+// NewHandlerFromFile creates a new Handler from the contents of the file at path
+// It is intended for replaying recorded responses and fixture-driven tests
+func NewHandlerFromFile(path string) (*Handler, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fileReadError(path, err)
+	}
+	return newHandlerFromFileBody(path, body)
+}
+
+// NewHandlerFromFS creates a new Handler from the named file in fsys
+// It works with embed.FS and testing/fstest.MapFS
+func NewHandlerFromFS(fsys fs.FS, name string) (*Handler, error) {
+	if fsys == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeFileRead,
+			Message: "file system is nil",
+			Context: map[string]interface{}{
+				"path": name,
+			},
+		}
+	}
+
+	body, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fileReadError(name, err)
+	}
+	return newHandlerFromFileBody(name, body)
+}
+
+// newHandlerFromFileBody parses body and records the source path on failure
+func newHandlerFromFileBody(path string, body []byte) (*Handler, error) {
+	handler, err := NewHandler(body)
+	if err != nil {
+		return nil, withContext(err, map[string]interface{}{
+			"path": path,
+		})
+	}
+	return handler, nil
+}
+
+// fileReadError wraps a file read failure with its path
+func fileReadError(path string, err error) error {
+	return &ValidationError{
+		Code:    ErrCodeFileRead,
+		Message: "failed to read response file",
+		Err:     err,
+		Context: map[string]interface{}{
+			"path": path,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package toon
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHandlerFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fixtures/user.json":    {Data: []byte(`{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-123"}}`)},
+		"fixtures/invalid.json": {Data: []byte(`{invalid json}`)},
+	}
+
+	handler, err := NewHandlerFromFS(fsys, "fixtures/user.json")
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "req-123", handler.GetRequestID())
+
+	_, err = NewHandlerFromFS(fsys, "fixtures/missing.json")
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeFileRead, valErr.Code)
+	assert.Equal(t, "fixtures/missing.json", valErr.Context["path"])
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = NewHandlerFromFS(fsys, "fixtures/invalid.json")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Equal(t, "fixtures/invalid.json", valErr.Context["path"])
+}
+
+func TestNewHandlerFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "response.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"success": false, "error": {"code": "ERR", "message": "msg"}}`), 0o600))
+
+	handler, err := NewHandlerFromFile(path)
+	require.NoError(t, err)
+	assert.True(t, handler.IsError())
+
+	_, err = NewHandlerFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeFileRead, valErr.Code)
+}
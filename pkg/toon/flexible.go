@@ -0,0 +1,215 @@
+package toon
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnmarshalDataFlexible unmarshals the response data into v, matching object
+// keys to struct fields regardless of casing or snake/camel/kebab convention
+// For example "user_name", "userName" and "UserName" all match a field tagged
+// `json:"userName"`
+// A key spelled exactly like the field's json name wins over other spellings;
+// two other spellings of the same field are rejected as ambiguous
+// It is slower than UnmarshalData and meant for structs whose tags you cannot control
+func (h *Handler) UnmarshalDataFlexible(v interface{}) error {
+	if v == nil {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "target interface is nil",
+		}
+	}
+
+	dec, err := h.DataDecoder(UseNumber())
+	if err != nil {
+		return err
+	}
+
+	var decoded interface{}
+	if err := dec.Decode(&decoded); err != nil {
+		return flexibleDecodeError(err, v)
+	}
+
+	remappedValue, err := remapKeys(decoded, reflect.TypeOf(v))
+	if err != nil {
+		return flexibleDecodeError(err, v)
+	}
+
+	remapped, err := json.Marshal(remappedValue)
+	if err != nil {
+		return flexibleDecodeError(err, v)
+	}
+
+	if err := json.Unmarshal(remapped, v); err != nil {
+		return flexibleDecodeError(err, v)
+	}
+	return nil
+}
+
+// flexibleDecodeError wraps a decode failure in UnmarshalDataFlexible
+func flexibleDecodeError(err error, v interface{}) error {
+	return &ValidationError{
+		Code:    ErrCodeJSONUnmarshal,
+		Message: "failed to unmarshal data into target type",
+		Err:     err,
+		Context: map[string]interface{}{
+			"target": fmt.Sprintf("%T", v),
+		},
+	}
+}
+
+// canonicalKey lowercases a key and drops separators so that naming
+// conventions compare equal
+func canonicalKey(key string) string {
+	var b strings.Builder
+	b.Grow(len(key))
+	for _, r := range key {
+		switch r {
+		case '_', '-', ' ':
+			continue
+		}
+		b.WriteString(strings.ToLower(string(r)))
+	}
+	return b.String()
+}
+
+// remapKeys rewrites object keys in decoded JSON to the json names of the
+// matching struct fields of t, recursing into nested structs, slices and maps
+// When several keys match the same field, a key equal to the field's json
+// name wins; otherwise the match is ambiguous and an error is returned
+func remapKeys(v interface{}, t reflect.Type) (interface{}, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return v, nil
+		}
+
+		fields := structFieldsByCanonicalKey(t)
+		matches := make(map[string][]string)
+		out := make(map[string]interface{}, len(obj))
+		for key, value := range obj {
+			if field, ok := fields[canonicalKey(key)]; ok {
+				matches[field.name] = append(matches[field.name], key)
+				continue
+			}
+			out[key] = value
+		}
+
+		for _, field := range fields {
+			keys := matches[field.name]
+			if len(keys) == 0 {
+				continue
+			}
+
+			key, err := pickFlexibleKey(field.name, keys)
+			if err != nil {
+				return nil, err
+			}
+			value, err := remapKeys(obj[key], field.typ)
+			if err != nil {
+				return nil, err
+			}
+			out[field.name] = value
+		}
+		return out, nil
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return v, nil
+		}
+		for i, item := range arr {
+			remapped, err := remapKeys(item, t.Elem())
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = remapped
+		}
+		return arr, nil
+	case reflect.Map:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return v, nil
+		}
+		for key, value := range obj {
+			remapped, err := remapKeys(value, t.Elem())
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = remapped
+		}
+		return obj, nil
+	default:
+		return v, nil
+	}
+}
+
+// pickFlexibleKey chooses which of the keys matching field supplies its value
+func pickFlexibleKey(field string, keys []string) (string, error) {
+	if len(keys) == 1 {
+		return keys[0], nil
+	}
+	for _, key := range keys {
+		if key == field {
+			return key, nil
+		}
+	}
+
+	sort.Strings(keys)
+	return "", fmt.Errorf("keys %q all match field %q", keys, field)
+}
+
+// flexibleField is the json name and type of a struct field
+type flexibleField struct {
+	name string
+	typ  reflect.Type
+}
+
+// structFieldsByCanonicalKey indexes the json fields of t, including promoted
+// fields of embedded structs, by their canonical key
+func structFieldsByCanonicalKey(t reflect.Type) map[string]flexibleField {
+	fields := make(map[string]flexibleField, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, field := range structFieldsByCanonicalKey(embedded) {
+					if _, exists := fields[key]; !exists {
+						fields[key] = field
+					}
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[canonicalKey(name)] = flexibleField{name: name, typ: f.Type}
+	}
+	return fields
+}
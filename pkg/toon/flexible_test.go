@@ -0,0 +1,104 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flexibleAddress struct {
+	StreetName string `json:"streetName"`
+	PostalCode string `json:"postalCode"`
+}
+
+type flexibleUser struct {
+	UserID    int               `json:"userId"`
+	FirstName string            `json:"firstName"`
+	Addresses []flexibleAddress `json:"addresses"`
+	Primary   *flexibleAddress  `json:"primaryAddress"`
+}
+
+func TestUnmarshalDataFlexibleSnakeToCamel(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"data": {
+			"user_id": 42,
+			"first_name": "Ada",
+			"addresses": [{"street_name": "Main St", "postal_code": "12345"}],
+			"primary_address": {"street_name": "Elm St", "postal_code": "54321"}
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var strict flexibleUser
+	require.NoError(t, handler.UnmarshalData(&strict))
+	assert.Zero(t, strict.UserID)
+
+	var user flexibleUser
+	require.NoError(t, handler.UnmarshalDataFlexible(&user))
+	assert.Equal(t, 42, user.UserID)
+	assert.Equal(t, "Ada", user.FirstName)
+	require.Len(t, user.Addresses, 1)
+	assert.Equal(t, "Main St", user.Addresses[0].StreetName)
+	require.NotNil(t, user.Primary)
+	assert.Equal(t, "54321", user.Primary.PostalCode)
+}
+
+func TestUnmarshalDataFlexibleCamelToSnake(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"userName": "ada", "Created-At": "today"}}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var target struct {
+		UserName  string `json:"user_name"`
+		CreatedAt string `json:"created_at"`
+	}
+	require.NoError(t, handler.UnmarshalDataFlexible(&target))
+	assert.Equal(t, "ada", target.UserName)
+	assert.Equal(t, "today", target.CreatedAt)
+}
+
+func TestUnmarshalDataFlexibleErrors(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"user_id": "not a number"}}`))
+	require.NoError(t, err)
+
+	var user flexibleUser
+	err = handler.UnmarshalDataFlexible(&user)
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+
+	require.ErrorAs(t, handler.UnmarshalDataFlexible(nil), &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+}
+
+func TestUnmarshalDataFlexiblePrefersExactKey(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"UserId": 1, "userId": 2, "user_id": 3}}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		var user flexibleUser
+		require.NoError(t, handler.UnmarshalDataFlexible(&user))
+		assert.Equal(t, 2, user.UserID)
+	}
+}
+
+func TestUnmarshalDataFlexibleAmbiguousKeys(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"UserId": 1, "user_id": 3}}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var user flexibleUser
+	err = handler.UnmarshalDataFlexible(&user)
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Contains(t, err.Error(), `"UserId" "user_id"`)
+}
@@ -0,0 +1,156 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+)
+
+// gobHandler is the serialized form of a Handler
+// Only the raw body, the responseState and the options are stored; everything
+// else is rebuilt by parsing the body on decode
+type gobHandler struct {
+	Body []byte
+
+	// responseState
+	Latency        time.Duration
+	RetryAfter     time.Duration
+	HasRetryAfter  bool
+	ExpiresAt      time.Time
+	HasExpiry      bool
+	StatusCode     int
+	Bare           bool
+	Warnings       []string
+	IdempotencyKey string
+	ParsedAt       time.Time
+
+	// handlerOptions
+	SuccessField         string
+	SuccessValue         string
+	PreserveData         bool
+	UnwrapStringData     bool
+	DecodeCache          bool
+	RootPath             string
+	DetectHTML           bool
+	IdempotencyKeyHeader string
+}
+
+// newGobHandler builds the serialized form of a handler's state
+func newGobHandler(body []byte, s responseState, o handlerOptions) gobHandler {
+	return gobHandler{
+		Body:                 body,
+		Latency:              s.latency,
+		RetryAfter:           s.retryAfter,
+		HasRetryAfter:        s.hasRetryAfter,
+		ExpiresAt:            s.expiresAt,
+		HasExpiry:            s.hasExpiry,
+		StatusCode:           s.statusCode,
+		Bare:                 s.bare,
+		Warnings:             s.headerWarnings,
+		IdempotencyKey:       s.idempotencyKey,
+		ParsedAt:             s.parsedAt,
+		SuccessField:         o.successField,
+		SuccessValue:         o.successValue,
+		PreserveData:         o.preserveData,
+		UnwrapStringData:     o.unwrapStringData,
+		DecodeCache:          o.decodeCache,
+		RootPath:             o.rootPath,
+		DetectHTML:           o.detectHTML,
+		IdempotencyKeyHeader: o.idempotencyKeyHeader,
+	}
+}
+
+// state returns the responseState stored in g
+func (g *gobHandler) state() responseState {
+	return responseState{
+		latency:        g.Latency,
+		retryAfter:     g.RetryAfter,
+		hasRetryAfter:  g.HasRetryAfter,
+		expiresAt:      g.ExpiresAt,
+		hasExpiry:      g.HasExpiry,
+		statusCode:     g.StatusCode,
+		bare:           g.Bare,
+		headerWarnings: g.Warnings,
+		idempotencyKey: g.IdempotencyKey,
+		parsedAt:       g.ParsedAt,
+	}
+}
+
+// options returns the handlerOptions stored in g
+func (g *gobHandler) options() handlerOptions {
+	return handlerOptions{
+		successField:         g.SuccessField,
+		successValue:         g.SuccessValue,
+		preserveData:         g.PreserveData,
+		unwrapStringData:     g.UnwrapStringData,
+		decodeCache:          g.DecodeCache,
+		rootPath:             g.RootPath,
+		detectHTML:           g.DetectHTML,
+		idempotencyKeyHeader: g.IdempotencyKeyHeader,
+	}
+}
+
+// GobEncode implements gob.GobEncoder so handlers can be stored in gob-based caches
+// Handlers created with WithDataTransform cannot be encoded, since functions
+// cannot be serialized
+func (h *Handler) GobEncode() ([]byte, error) {
+	if h == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	h.mu.RLock()
+	g := newGobHandler(h.body, h.responseState, h.opts)
+	transforms := len(h.opts.dataTransforms)
+	h.mu.RUnlock()
+
+	if transforms > 0 {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "handler with data transforms cannot be gob encoded",
+			Context: map[string]interface{}{
+				"transforms": transforms,
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder
+// The body is parsed again with the options stored by GobEncode, which are
+// all options except data transforms, and all derived state is reinitialized
+func (h *Handler) GobDecode(data []byte) error {
+	var g gobHandler
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+
+	parsed := &Handler{}
+	var err error
+	switch {
+	case g.Bare:
+		if parsed, err = newBareHandler(g.Body, g.StatusCode); err != nil {
+			return err
+		}
+	case len(g.Body) > 0:
+		// The stored body is already the object found at the root path
+		o := g.options()
+		o.rootPath = ""
+		if parsed, err = newHandlerWithOptions(g.Body, o); err != nil {
+			return err
+		}
+		parsed.opts.rootPath = g.RootPath
+	}
+
+	parsed.responseState = g.state()
+
+	h.replaceWith(parsed)
+	return nil
+}
@@ -0,0 +1,109 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{"Cache-Control": {"max-age=60"}},
+		`{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-gob"}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	original, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	type cacheEntry struct {
+		Key     string
+		Handler *Handler
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(cacheEntry{Key: "users/1", Handler: original}))
+
+	var restored cacheEntry
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&restored))
+	require.NotNil(t, restored.Handler)
+
+	h := restored.Handler
+	assert.Equal(t, "users/1", restored.Key)
+	assert.True(t, h.IsSuccess())
+	assert.Equal(t, "req-gob", h.GetRequestID())
+	assert.Equal(t, original.RawBody(), h.RawBody())
+	assert.Equal(t, http.StatusOK, h.StatusCode())
+
+	originalExpiry, _ := original.ExpiresAt()
+	restoredExpiry, ok := h.ExpiresAt()
+	assert.True(t, ok)
+	assert.True(t, originalExpiry.Equal(restoredExpiry))
+
+	// The restored handler must be fully usable, including its lock
+	require.NoError(t, h.SetRequestID("req-updated"))
+	assert.Equal(t, "req-updated", h.GetRequestID())
+}
+
+func TestGobRoundTripKeepsOptions(t *testing.T) {
+	original, err := NewHandlerWithOptions([]byte(`{"ok": true}`), WithSuccessField("ok"))
+	require.NoError(t, err)
+	original.latency = 25 * time.Millisecond
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(original))
+
+	var restored Handler
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&restored))
+	assert.True(t, restored.IsSuccess())
+	assert.Equal(t, 25*time.Millisecond, restored.Latency())
+}
+
+func TestGobRoundTripKeepsDecodeOptions(t *testing.T) {
+	original, err := NewHandlerWithOptions([]byte(`{"success": true, "data": "{\"id\": 3}"}`),
+		WithUnwrapStringData(), WithDecodeCache())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(original))
+
+	var restored Handler
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&restored))
+	assert.Equal(t, original.options(), restored.options())
+
+	var data struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, restored.UnmarshalData(&data))
+	assert.Equal(t, 3, data.ID)
+}
+
+func TestGobRoundTripKeepsHTTPOptions(t *testing.T) {
+	original, err := NewHandlerWithOptions([]byte(`{"result": {"success": true, "data": {"id": 4}}}`),
+		WithRootPath("result"), WithHTMLErrorPages(), WithIdempotencyKeyHeader("X-Request-Key"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(original))
+
+	var restored Handler
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&restored))
+	assert.Equal(t, original.options(), restored.options())
+	assert.JSONEq(t, `{"id": 4}`, string(restored.GetData()))
+}
+
+func TestGobEncodeRejectsDataTransforms(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{"success": true, "data": {"id": 5}}`),
+		WithDataTransform(func(data json.RawMessage) (json.RawMessage, error) { return data, nil }))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(handler)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "data transforms")
+}
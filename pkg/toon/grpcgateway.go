@@ -0,0 +1,129 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// grpcCodeNames maps gRPC status codes to their canonical names
+var grpcCodeNames = map[int]string{
+	0:  "OK",
+	1:  "CANCELLED",
+	2:  "UNKNOWN",
+	3:  "INVALID_ARGUMENT",
+	4:  "DEADLINE_EXCEEDED",
+	5:  "NOT_FOUND",
+	6:  "ALREADY_EXISTS",
+	7:  "PERMISSION_DENIED",
+	8:  "RESOURCE_EXHAUSTED",
+	9:  "FAILED_PRECONDITION",
+	10: "ABORTED",
+	11: "OUT_OF_RANGE",
+	12: "UNIMPLEMENTED",
+	13: "INTERNAL",
+	14: "UNAVAILABLE",
+	15: "DATA_LOSS",
+	16: "UNAUTHENTICATED",
+}
+
+// grpcGatewayError is the error body emitted by grpc-gateway
+type grpcGatewayError struct {
+	Code    *int            `json:"code"`
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details"`
+}
+
+// NewGRPCGatewayHandler creates a Handler from a grpc-gateway response, which
+// has no Toon envelope: success is derived from the HTTP status, a 2xx body
+// becomes the data, and an error body of the form
+// {"code": N, "message": "...", "details": [...]} becomes the response error
+// The error code is the canonical gRPC name (e.g. NOT_FOUND), the details are
+// kept as raw JSON in Details, and the numeric code is kept in Extra["grpc_code"]
+func NewGRPCGatewayHandler(body []byte, statusCode int) (*Handler, error) {
+	body = stripBOM(body)
+
+	if statusCode >= 200 && statusCode < 300 {
+		var data json.RawMessage
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 {
+			if !json.Valid(trimmed) {
+				return nil, &ValidationError{
+					Code:    ErrCodeJSONUnmarshal,
+					Message: "failed to unmarshal response body",
+					Context: map[string]interface{}{
+						"body_size":   len(body),
+						"status_code": statusCode,
+					},
+				}
+			}
+			data = json.RawMessage(trimmed)
+		}
+
+		return &Handler{
+			resp:          &Response{Success: true, Data: data},
+			body:          body,
+			responseState: responseState{statusCode: statusCode, parsedAt: Now()},
+		}, nil
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, &ValidationError{
+			Code:    ErrCodeEmptyResponse,
+			Message: "body is empty",
+			Context: map[string]interface{}{
+				"status_code": statusCode,
+			},
+		}
+	}
+
+	var gwErr grpcGatewayError
+	if err := json.Unmarshal(body, &gwErr); err != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal response body",
+			Err:     err,
+			Context: map[string]interface{}{
+				"body_size":   len(body),
+				"status_code": statusCode,
+			},
+		}
+	}
+
+	if gwErr.Code == nil && gwErr.Message == "" {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "grpc-gateway error body has no code or message",
+			Context: map[string]interface{}{
+				"status_code": statusCode,
+			},
+		}
+	}
+
+	respErr := &ResponseError{Message: gwErr.Message}
+	if gwErr.Code != nil {
+		respErr.Code = grpcCodeName(*gwErr.Code)
+		respErr.Extra = map[string]json.RawMessage{
+			"grpc_code": json.RawMessage(strconv.Itoa(*gwErr.Code)),
+		}
+	}
+	if details := bytes.TrimSpace(gwErr.Details); len(details) > 0 &&
+		!bytes.Equal(details, []byte("null")) && !bytes.Equal(details, []byte("[]")) {
+		respErr.Details = string(details)
+	}
+
+	return &Handler{
+		resp:          &Response{Success: false, Error: respErr},
+		body:          body,
+		rawError:      json.RawMessage(body),
+		responseState: responseState{statusCode: statusCode, parsedAt: Now()},
+	}, nil
+}
+
+// grpcCodeName returns the canonical name of a gRPC status code, or the
+// number itself for codes outside the standard range
+func grpcCodeName(code int) string {
+	if name, ok := grpcCodeNames[code]; ok {
+		return name
+	}
+	return strconv.Itoa(code)
+}
@@ -0,0 +1,86 @@
+package toon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gatewayErrorBody = `{
+	"code": 5,
+	"message": "user 42 not found",
+	"details": [
+		{"@type": "type.googleapis.com/google.rpc.ResourceInfo", "resource_name": "users/42"}
+	]
+}`
+
+func TestNewGRPCGatewayHandlerError(t *testing.T) {
+	handler, err := NewGRPCGatewayHandler([]byte(gatewayErrorBody), http.StatusNotFound)
+	require.NoError(t, err)
+
+	assert.False(t, handler.IsSuccess())
+	assert.True(t, handler.IsError())
+	assert.Equal(t, http.StatusNotFound, handler.StatusCode())
+	assert.NoError(t, handler.Validate())
+
+	respErr := handler.GetError()
+	require.NotNil(t, respErr)
+	assert.Equal(t, "NOT_FOUND", respErr.Code)
+	assert.Equal(t, "user 42 not found", respErr.Message)
+	assert.Contains(t, respErr.Details, "users/42")
+	assert.JSONEq(t, `5`, string(respErr.Extra["grpc_code"]))
+}
+
+func TestNewGRPCGatewayHandlerErrorWithoutDetails(t *testing.T) {
+	body := []byte(`{"code": 99, "message": "custom", "details": []}`)
+
+	handler, err := NewGRPCGatewayHandler(body, http.StatusInternalServerError)
+	require.NoError(t, err)
+
+	respErr := handler.GetError()
+	require.NotNil(t, respErr)
+	assert.Equal(t, "99", respErr.Code)
+	assert.Empty(t, respErr.Details)
+}
+
+func TestNewGRPCGatewayHandlerSuccess(t *testing.T) {
+	handler, err := NewGRPCGatewayHandler([]byte(`{"id": 42, "name": "Jane"}`), http.StatusOK)
+	require.NoError(t, err)
+
+	assert.True(t, handler.IsSuccess())
+	assert.Nil(t, handler.GetError())
+
+	var user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	require.NoError(t, handler.UnmarshalData(&user))
+	assert.Equal(t, 42, user.ID)
+	assert.Equal(t, "Jane", user.Name)
+}
+
+func TestNewGRPCGatewayHandlerInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   ErrCode
+	}{
+		{"invalid success body", `{bad`, http.StatusOK, ErrCodeJSONUnmarshal},
+		{"invalid error body", `{bad`, http.StatusBadRequest, ErrCodeJSONUnmarshal},
+		{"empty error body", ``, http.StatusBadRequest, ErrCodeEmptyResponse},
+		{"not a gateway error", `{"other": 1}`, http.StatusBadRequest, ErrCodeInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGRPCGatewayHandler([]byte(tt.body), tt.status)
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, tt.code, valErr.Code)
+		})
+	}
+}
@@ -1,10 +1,16 @@
 package toon
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,28 +22,115 @@ type Handler struct {
 	body   []byte
 	rawErr error
 	mu     sync.RWMutex
+
+	// rawError holds the original bytes of the error object
+	rawError json.RawMessage
+
+	// opts holds the options the handler was created with
+	opts handlerOptions
+
+	responseState
+
+	// localMeta holds client-side annotations set with SetLocalMeta
+	localMeta map[string]interface{}
+
+	// dataCache memoizes decoded data by target type when WithDecodeCache is set
+	dataCache map[reflect.Type]reflect.Value
+}
+
+// responseState is the handler state that cannot be derived from the body
+// Scan and GobDecode carry it over as a whole when they replace a handler
+type responseState struct {
+	// latency is the upstream round trip time recorded by DoTimed
+	latency time.Duration
+
+	// retryAfter is parsed from the Retry-After header by FromHTTPResponse
+	retryAfter    time.Duration
+	hasRetryAfter bool
+
+	// expiresAt is derived from the caching headers by FromHTTPResponse
+	expiresAt time.Time
+	hasExpiry bool
+
+	// statusCode is the HTTP status recorded by FromHTTPResponse
+	statusCode int
+
+	// bare is set for handlers built by FromHTTPResponseBare, whose error
+	// is derived from statusCode
+	bare bool
+
+	// headerWarnings are the texts of HTTP Warning headers captured by FromHTTPResponse
+	headerWarnings []string
+
+	// idempotencyKey is the echoed idempotency key captured by FromHTTPResponse
+	idempotencyKey string
+
+	// parsedAt is the local time the body was parsed, used by ClockSkew
+	parsedAt time.Time
+}
+
+// envelope is the view of a response parsed by NewHandler in a single pass
+// Data and the error object are kept raw, so data is only decoded by the
+// accessors and vendor-specific error shapes remain available via GetErrorRaw
+type envelope struct {
+	Success *bool             `json:"success"`
+	Data    json.RawMessage   `json:"data,omitempty"`
+	Error   json.RawMessage   `json:"error,omitempty"`
+	Errors  []*ResponseError  `json:"errors,omitempty"`
+	Meta    *Meta             `json:"meta,omitempty"`
+	Results []json.RawMessage `json:"results,omitempty"`
+}
+
+// succeeded reports the success flag, treating an absent flag as false
+func (env envelope) succeeded() bool {
+	return env.Success != nil && *env.Success
 }
 
 // NewHandler creates a new Handler from raw bytes
 // It performs comprehensive validation and error handling
+// A leading UTF-8 byte order mark is ignored; RawBody still returns it
+// The data is kept as raw JSON and only decoded by the data accessors
 func NewHandler(body []byte) (*Handler, error) {
+	env, respErr, err := parseEnvelope(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		resp: &Response{
+			Success: env.succeeded(),
+			Data:    env.Data,
+			Error:   respErr,
+			Errors:  env.Errors,
+			Meta:    env.Meta,
+			Results: env.Results,
+		},
+		body:          body,
+		rawError:      env.Error,
+		responseState: responseState{parsedAt: Now()},
+	}, nil
+}
+
+// parseEnvelope validates body and decodes its envelope
+// It implements the checks shared by NewHandler and DecodeInto
+func parseEnvelope(body []byte) (envelope, *ResponseError, error) {
 	if body == nil {
-		return nil, &ValidationError{
+		return envelope{}, nil, &ValidationError{
 			Code:    ErrCodeEmptyResponse,
 			Message: "body is nil",
 		}
 	}
 
 	if len(body) == 0 {
-		return nil, &ValidationError{
+		return envelope{}, nil, &ValidationError{
 			Code:    ErrCodeEmptyResponse,
 			Message: "body is empty",
 		}
 	}
 
-	var resp Response
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, &ValidationError{
+	var env envelope
+	if err := json.Unmarshal(stripBOM(body), &env); err != nil {
+		return envelope{}, nil, &ValidationError{
 			Code:    ErrCodeJSONUnmarshal,
 			Message: "failed to unmarshal response body",
 			Err:     err,
@@ -47,15 +140,198 @@ func NewHandler(body []byte) (*Handler, error) {
 		}
 	}
 
-	return &Handler{
-		resp: &resp,
-		body: body,
-	}, nil
+	var respErr *ResponseError
+	if len(env.Error) > 0 && string(env.Error) != "null" {
+		respErr = &ResponseError{}
+		if err := json.Unmarshal(env.Error, respErr); err != nil {
+			return envelope{}, nil, &ValidationError{
+				Code:    ErrCodeJSONUnmarshal,
+				Message: "failed to unmarshal response body",
+				Err:     err,
+				Context: map[string]interface{}{
+					"body_size": len(body),
+				},
+			}
+		}
+	} else {
+		env.Error = nil
+	}
+
+	// A multi-status body without a success flag is successful only if
+	// every sub-result is
+	if env.Success == nil && len(env.Results) > 0 {
+		success := allSucceeded(env.Results)
+		env.Success = &success
+	}
+
+	return env, respErr, nil
+}
+
+// DecodeInto parses body directly into a caller-supplied Response, applying
+// the same validation as NewHandler; it lets callers that pool Response
+// values manage their lifetimes without allocating a Handler
+// resp is reset first
+func DecodeInto(body []byte, resp *Response) error {
+	if resp == nil {
+		return &ValidationError{
+			Code:    ErrCodeNilResponse,
+			Message: "response is nil",
+		}
+	}
+	*resp = Response{}
+
+	env, respErr, err := parseEnvelope(body)
+	if err != nil {
+		return err
+	}
+
+	*resp = Response{
+		Success: env.succeeded(),
+		Data:    env.Data,
+		Error:   respErr,
+		Errors:  env.Errors,
+		Meta:    env.Meta,
+		Results: env.Results,
+	}
+	return nil
+}
+
+// NewHandlerFromMap creates a new Handler from a response built as a map
+// The map is marshaled and parsed by NewHandler, so the same validation applies
+func NewHandlerFromMap(m map[string]interface{}) (*Handler, error) {
+	if m == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeEmptyResponse,
+			Message: "map is nil",
+		}
+	}
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeJSONMarshal,
+			Message: "failed to marshal response map",
+			Err:     err,
+		}
+	}
+	return NewHandler(body)
+}
+
+// utf8BOM is the UTF-8 byte order mark some upstreams prefix to bodies
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// stripBOM returns body without a leading UTF-8 byte order mark
+// encoding/json tolerates leading whitespace but rejects a BOM
+func stripBOM(body []byte) []byte {
+	return bytes.TrimPrefix(body, utf8BOM)
 }
 
 // FromHTTPResponse creates a Handler from an HTTP response
 // It validates the response, reads the body, and handles errors comprehensively
+// When the body has no meta, request ID, API version and rate limit trailers
+// are merged into Meta; trailers are only available once the body is fully read
 func FromHTTPResponse(httpResp *http.Response) (*Handler, error) {
+	return fromHTTPResponse(context.Background(), httpResp, handlerOptions{})
+}
+
+// FromHTTPResponseWithOptions is like FromHTTPResponse but parses the body
+// with the given options, as NewHandlerWithOptions does
+func FromHTTPResponseWithOptions(httpResp *http.Response, opts ...Option) (*Handler, error) {
+	var o handlerOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return fromHTTPResponse(context.Background(), httpResp, o)
+}
+
+// FromHTTPResponseTimeout is like FromHTTPResponse but gives up reading the
+// body after d, returning a ValidationError with code READ_TIMEOUT
+// It protects against slow upstreams when the client has no timeout of its own
+func FromHTTPResponseTimeout(httpResp *http.Response, d time.Duration) (*Handler, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	handler, err := fromHTTPResponse(ctx, httpResp, handlerOptions{})
+	var valErr *ValidationError
+	if errors.As(err, &valErr) && valErr.Code == ErrCodeRequestCanceled &&
+		errors.Is(valErr.Err, context.DeadlineExceeded) {
+		valErr.Code = ErrCodeReadTimeout
+		valErr.Message = "timed out reading response body"
+		valErr.Context["timeout"] = d.String()
+	}
+	return handler, err
+}
+
+// fromHTTPResponse implements FromHTTPResponse
+// Errors are annotated with the originating request when it is known
+func fromHTTPResponse(ctx context.Context, httpResp *http.Response, o handlerOptions) (*Handler, error) {
+	handler, err := readHTTPResponse(ctx, httpResp, o)
+	if err != nil && httpResp != nil {
+		return nil, withContext(err, requestContext(httpResp.Request))
+	}
+	return handler, err
+}
+
+// readHTTPResponse reads and parses the response body
+// Canceling ctx aborts the body read and yields ErrCodeRequestCanceled
+func readHTTPResponse(ctx context.Context, httpResp *http.Response, o handlerOptions) (*Handler, error) {
+	body, err := readHTTPBody(ctx, httpResp)
+	if err != nil {
+		return nil, err
+	}
+	if o.detectHTML && isHTMLBody(body) {
+		return nil, htmlPageError(httpResp, body)
+	}
+	return parseHTTPBody(httpResp, body, o)
+}
+
+// parseHTTPBody builds the handler for httpResp from its already read body
+func parseHTTPBody(httpResp *http.Response, body []byte, o handlerOptions) (*Handler, error) {
+	handler, err := newHandlerWithOptions(body, o)
+	if err != nil {
+		return nil, withContext(err, map[string]interface{}{
+			"status_code":  httpResp.StatusCode,
+			"content_type": httpResp.Header.Get("Content-Type"),
+		})
+	}
+
+	// A 207 body is successful only if every sub-result is, whatever its
+	// own success flag says
+	if httpResp.StatusCode == http.StatusMultiStatus && len(handler.resp.Results) > 0 {
+		handler.resp.Success = allSucceeded(handler.resp.Results)
+	}
+
+	// Validate HTTP status code against response success flag
+	if (httpResp.StatusCode < 200 || httpResp.StatusCode >= 300) && handler.IsSuccess() {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidStatusCode,
+			Message: "http status code indicates error but response success is true",
+			Context: map[string]interface{}{
+				"status_code": httpResp.StatusCode,
+				"success":     handler.IsSuccess(),
+			},
+		}
+	}
+
+	handler.captureHTTPResponse(httpResp)
+	return handler, nil
+}
+
+// captureHTTPResponse records the status code and the header and trailer
+// derived state of httpResp once its body has been read
+func (h *Handler) captureHTTPResponse(httpResp *http.Response) {
+	h.captureHeaders(httpResp.Header)
+	h.captureTrailers(httpResp.Trailer)
+
+	h.mu.Lock()
+	h.statusCode = httpResp.StatusCode
+	h.mu.Unlock()
+}
+
+// readHTTPBody reads the complete, decompressed response body and closes it
+func readHTTPBody(ctx context.Context, httpResp *http.Response) ([]byte, error) {
 	if httpResp == nil {
 		return nil, &ValidationError{
 			Code:    ErrCodeInvalidResponse,
@@ -80,7 +356,65 @@ func FromHTTPResponse(httpResp *http.Response) (*Handler, error) {
 		}
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
+	// Close the body on cancellation so that a blocked read returns promptly
+	stop := context.AfterFunc(ctx, func() {
+		_ = httpResp.Body.Close()
+	})
+	defer stop()
+
+	// Decompress gzip bodies the transport left encoded, e.g. when the
+	// caller set Accept-Encoding explicitly
+	reader := io.Reader(&contextReader{ctx: ctx, r: httpResp.Body})
+	contentLength := httpResp.ContentLength
+	if isGzipEncoded(httpResp.Header) {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, &ValidationError{
+				Code:    ErrCodeIORead,
+				Message: "failed to decompress response body",
+				Err:     err,
+				Context: map[string]interface{}{
+					"status_code":      httpResp.StatusCode,
+					"content_encoding": httpResp.Header.Get("Content-Encoding"),
+				},
+			}
+		}
+		defer func() {
+			_ = gz.Close()
+		}()
+		reader = gz
+		contentLength = -1
+	}
+
+	body, err := io.ReadAll(reader)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeRequestCanceled,
+			Message: "request canceled while reading response body",
+			Err:     ctxErr,
+			Context: map[string]interface{}{
+				"status_code": httpResp.StatusCode,
+				"bytes_read":  len(body),
+			},
+		}
+	}
+	if isTruncated(err, body, contentLength) {
+		truncErr := &ValidationError{
+			Code:    ErrCodeTruncatedBody,
+			Message: "response body was truncated",
+			Err:     err,
+			Context: map[string]interface{}{
+				"status_code":    httpResp.StatusCode,
+				"bytes_read":     len(body),
+				"content_length": contentLength,
+			},
+		}
+		if isChunked(httpResp) {
+			truncErr.Message = "chunked response body ended before the terminating chunk"
+			truncErr.Context["transfer_encoding"] = "chunked"
+		}
+		return nil, truncErr
+	}
 	if err != nil {
 		return nil, &ValidationError{
 			Code:    ErrCodeIORead,
@@ -92,24 +426,67 @@ func FromHTTPResponse(httpResp *http.Response) (*Handler, error) {
 		}
 	}
 
-	handler, err := NewHandler(body)
+	return body, nil
+}
+
+// contextReader is an io.Reader that fails with the context error once ctx is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := cr.r.Read(p)
 	if err != nil {
-		return nil, err
+		if ctxErr := cr.ctx.Err(); ctxErr != nil {
+			return n, ctxErr
+		}
 	}
+	return n, err
+}
 
-	// Validate HTTP status code against response success flag
-	if (httpResp.StatusCode < 200 || httpResp.StatusCode >= 300) && handler.IsSuccess() {
-		return nil, &ValidationError{
-			Code:    ErrCodeInvalidStatusCode,
-			Message: "http status code indicates error but response success is true",
-			Context: map[string]interface{}{
-				"status_code": httpResp.StatusCode,
-				"success":     handler.IsSuccess(),
-			},
+// isGzipEncoded reports whether the header declares a gzip content encoding
+func isGzipEncoded(header http.Header) bool {
+	return strings.EqualFold(strings.TrimSpace(header.Get("Content-Encoding")), "gzip")
+}
+
+// isTruncated reports whether a body read ended before the full body arrived
+// This is either an unexpected EOF from the transport, which is also how a
+// chunked stream missing its terminating chunk ends, or fewer bytes than the
+// declared Content-Length; other read errors are not truncation
+func isTruncated(readErr error, body []byte, contentLength int64) bool {
+	if errors.Is(readErr, io.ErrUnexpectedEOF) {
+		return true
+	}
+	return readErr == nil && contentLength > 0 && int64(len(body)) < contentLength
+}
+
+// isChunked reports whether the response body used chunked transfer encoding
+func isChunked(httpResp *http.Response) bool {
+	for _, te := range httpResp.TransferEncoding {
+		if strings.EqualFold(te, "chunked") {
+			return true
 		}
 	}
+	return false
+}
 
-	return handler, nil
+// StatusCode returns the HTTP status code of the response the handler was read from
+// Returns zero for handlers not created from an HTTP response
+func (h *Handler) StatusCode() int {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.statusCode
 }
 
 // IsSuccess safely checks if the response indicates success
@@ -148,11 +525,72 @@ func (h *Handler) GetError() *ResponseError {
 // ErrorString returns a formatted error string
 // Returns empty string if no error is present
 func (h *Handler) ErrorString() string {
+	return formatResponseError(h.GetError(), false)
+}
+
+// ErrorStringVerbose returns a formatted error string including the
+// server-provided origin request ID and trace when present
+// Returns empty string if no error is present
+func (h *Handler) ErrorStringVerbose() string {
+	return formatResponseError(h.GetError(), true)
+}
+
+// maxCauseDepth bounds how many causes ErrorStringWithCauses renders
+const maxCauseDepth = 32
+
+// ErrorStringWithCauses returns the ErrorString format followed by each
+// error in the cause chain, e.g. "A | failed | caused by: B | timeout"
+// Returns empty string if no error is present
+func (h *Handler) ErrorStringWithCauses() string {
+	if h == nil {
+		return ""
+	}
+
+	respErr := h.GetError()
+	result := formatResponseError(respErr, false)
+	if respErr == nil {
+		return result
+	}
+
+	cause := respErr.Cause
+	for depth := 0; cause != nil && depth < maxCauseDepth; depth++ {
+		result += " | caused by: " + formatResponseError(cause, false)
+		cause = cause.Cause
+	}
+	return result
+}
+
+// FormatError renders the error using a template with the placeholders
+// {code}, {message}, {details}, {field}, {request_id} and {trace}
+// Missing fields render as empty; unknown placeholders are left as is
+// Returns empty string if no error is present
+func (h *Handler) FormatError(template string) string {
+	if h == nil {
+		return ""
+	}
+
 	err := h.GetError()
 	if err == nil {
 		return ""
 	}
 
+	return strings.NewReplacer(
+		"{code}", err.Code,
+		"{message}", err.Message,
+		"{details}", err.Details,
+		"{field}", err.Field,
+		"{request_id}", err.RequestID,
+		"{trace}", err.Trace,
+	).Replace(template)
+}
+
+// formatResponseError joins the error fields with " | "
+// When verbose is set, the origin request ID and trace are appended
+func formatResponseError(err *ResponseError, verbose bool) string {
+	if err == nil {
+		return ""
+	}
+
 	parts := []string{err.Code}
 	if err.Message != "" {
 		parts = append(parts, err.Message)
@@ -163,6 +601,14 @@ func (h *Handler) ErrorString() string {
 	if err.Field != "" {
 		parts = append(parts, fmt.Sprintf("field: %s", err.Field))
 	}
+	if verbose {
+		if err.RequestID != "" {
+			parts = append(parts, fmt.Sprintf("request_id: %s", err.RequestID))
+		}
+		if err.Trace != "" {
+			parts = append(parts, fmt.Sprintf("trace: %s", err.Trace))
+		}
+	}
 
 	result := ""
 	for i, part := range parts {
@@ -194,8 +640,53 @@ func (h *Handler) GetData() json.RawMessage {
 	return data
 }
 
+// IsEmpty checks if the response is a success without data
+// Unlike len(GetData()) == 0 it does not copy the data
+func (h *Handler) IsEmpty() bool {
+	return h.IsSuccess() && h.DataLen() == 0
+}
+
+// DataLen returns the length of the raw data without the copy GetData makes
+// It is a cheap size check for metrics and "is there a lot of data?" branches
+func (h *Handler) DataLen() int {
+	if h == nil {
+		return 0
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil {
+		return 0
+	}
+	return len(h.resp.Data)
+}
+
+// GetErrorRaw safely returns a copy of the raw JSON of the error object
+// It allows callers to decode vendor-specific error shapes
+// Returns nil if no error is present
+func (h *Handler) GetErrorRaw() json.RawMessage {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.rawError) == 0 {
+		return nil
+	}
+
+	raw := make(json.RawMessage, len(h.rawError))
+	copy(raw, h.rawError)
+	return raw
+}
+
 // UnmarshalData safely unmarshals the response data into the provided interface
+// Any transforms registered with WithDataTransform are applied first; with
+// WithDecodeCache, repeated calls for the same target type reuse the decoded value
 // Returns ValidationError if data is empty or unmarshal fails
+// Each call decodes a private copy of the data and returns a fresh error,
+// so it is safe to call concurrently
 func (h *Handler) UnmarshalData(v interface{}) error {
 	if v == nil {
 		return &ValidationError{
@@ -204,26 +695,22 @@ func (h *Handler) UnmarshalData(v interface{}) error {
 		}
 	}
 
-	data := h.GetData()
-	if len(data) == 0 {
-		return &ValidationError{
-			Code:    ErrCodeEmptyData,
-			Message: "response data is empty",
-		}
+	if h.loadCachedData(v) {
+		return nil
 	}
 
-	if err := json.Unmarshal(data, v); err != nil {
-		return &ValidationError{
-			Code:    ErrCodeJSONUnmarshal,
-			Message: "failed to unmarshal data into target type",
-			Err:     err,
-			Context: map[string]interface{}{
-				"data_size": len(data),
-				"target":    fmt.Sprintf("%T", v),
-			},
-		}
+	data, err := h.decodableData()
+	if err != nil {
+		return err
+	}
+
+	if ok, err := h.decodeCachedData(data, v); ok {
+		return err
 	}
 
+	if err := json.Unmarshal(data, v); err != nil {
+		return decodeError(err, len(data), v)
+	}
 	return nil
 }
 
@@ -238,6 +725,35 @@ func (h *Handler) GetMeta() *Meta {
 	return h.resp.Meta
 }
 
+// MetaValue returns a copy of the metadata by value, with zero fields when
+// meta is absent, so h.MetaValue().RequestID never needs a nil check
+// The copy shares nothing with the handler and may be mutated freely
+func (h *Handler) MetaValue() Meta {
+	if h == nil {
+		return Meta{}
+	}
+	if meta := h.GetMeta().clone(); meta != nil {
+		return *meta
+	}
+	return Meta{}
+}
+
+// MetaField returns the raw value of a vendor-specific meta field, i.e. one
+// not decoded into a named Meta field
+// Returns false if the field is absent
+func (h *Handler) MetaField(key string) (json.RawMessage, bool) {
+	if h == nil {
+		return nil, false
+	}
+
+	meta := h.GetMeta()
+	if meta == nil {
+		return nil, false
+	}
+	value, ok := meta.Extra[key]
+	return value, ok
+}
+
 // GetRequestID safely returns the request ID from metadata if available
 func (h *Handler) GetRequestID() string {
 	meta := h.GetMeta()
@@ -256,9 +772,22 @@ func (h *Handler) GetRateLimit() *RateLimit {
 	return meta.RateLimit
 }
 
+// RateLimitValue returns the rate limit by value, or the zero RateLimit
+// when rate limit information is absent
+func (h *Handler) RateLimitValue() RateLimit {
+	if h == nil {
+		return RateLimit{}
+	}
+	if rl := h.GetRateLimit(); rl != nil {
+		return *rl
+	}
+	return RateLimit{}
+}
+
 // IsRateLimited checks if the request was rate limited based on remaining quota
+// Named rate limit buckets are considered as well as the single rate limit
 func (h *Handler) IsRateLimited() bool {
-	rl := h.GetRateLimit()
+	rl := h.MostConstrainedLimit()
 	if rl == nil {
 		return false
 	}
@@ -308,6 +837,50 @@ func (h *Handler) GetTimestamp() *time.Time {
 	return &meta.Timestamp
 }
 
+// GetWarnings safely returns a copy of the warnings from metadata followed by
+// the texts of any HTTP Warning headers captured by FromHTTPResponse
+func (h *Handler) GetWarnings() []string {
+	if h == nil {
+		return nil
+	}
+
+	var warnings []string
+	if meta := h.GetMeta(); meta != nil {
+		warnings = append(warnings, meta.Warnings...)
+	}
+
+	h.mu.RLock()
+	warnings = append(warnings, h.headerWarnings...)
+	h.mu.RUnlock()
+
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+// GetSunset safely returns the sunset time after which the API is retired
+func (h *Handler) GetSunset() *time.Time {
+	meta := h.GetMeta()
+	if meta == nil || meta.Sunset == nil || meta.Sunset.IsZero() {
+		return nil
+	}
+	return meta.Sunset
+}
+
+// IsDeprecated checks if the response signals deprecation
+// It returns true when the meta carries warnings or the sunset time is in
+// the future; HTTP Warning headers, which mostly carry cache notices, are
+// not considered
+func (h *Handler) IsDeprecated() bool {
+	if meta := h.GetMeta(); meta != nil && len(meta.Warnings) > 0 {
+		return true
+	}
+
+	sunset := h.GetSunset()
+	return sunset != nil && sunset.After(Now())
+}
+
 // String returns a formatted string representation of the response
 func (h *Handler) String() string {
 	if h == nil || h.resp == nil {
@@ -345,7 +918,7 @@ func (h *Handler) RawBody() []byte {
 }
 
 // Response returns the underlying Response struct
-// Callers should not modify the returned struct
+// Callers should not modify the returned struct; use ResponseCopy for a mutable copy
 func (h *Handler) Response() *Response {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
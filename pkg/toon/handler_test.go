@@ -1,8 +1,14 @@
 package toon
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -125,6 +131,29 @@ func TestFromHTTPResponseWithStatusCodeMismatch(t *testing.T) {
 	assert.Equal(t, ErrCodeInvalidStatusCode, valErr.Code)
 }
 
+func TestFromHTTPResponseWithInvalidJSONKeepsHTTPContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{invalid json}`))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	assert.Error(t, err)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Equal(t, http.StatusInternalServerError, valErr.Context["status_code"])
+	assert.Equal(t, "application/json", valErr.Context["content_type"])
+	assert.Equal(t, len(`{invalid json}`), valErr.Context["body_size"])
+}
+
 func TestUnmarshalData(t *testing.T) {
 	type TestData struct {
 		ID   int    `json:"id"`
@@ -403,6 +432,62 @@ func BenchmarkNewHandler(b *testing.B) {
 	}
 }
 
+func TestNewHandlerKeepsDataRaw(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"data": {"id": 1},
+		"meta": {"request_id": "req-123", "timestamp": "2025-01-01T00:00:00Z"}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": 1}`, string(handler.resp.Data))
+	require.NotNil(t, handler.resp.Meta)
+	assert.Equal(t, "req-123", handler.GetRequestID())
+
+	assert.JSONEq(t, `{"id": 1}`, string(handler.GetData()))
+	assert.JSONEq(t, `{"id": 1}`, string(handler.Response().Data))
+}
+
+// benchmarkBody is the response parsed by the NewHandler benchmarks
+var benchmarkBody = []byte(`{
+	"success": true,
+	"data": {"id": 1, "name": "test"},
+	"meta": {
+		"request_id": "req-123",
+		"api_version": "v1",
+		"timestamp": "2025-01-01T00:00:00Z",
+		"rate_limit": {"limit": 1000, "remaining": 500, "reset": "2025-01-01T01:00:00Z"}
+	}
+}`)
+
+// BenchmarkNewHandlerEager is the baseline for the NewHandler benchmarks:
+// the whole body decoded straight into a Response in one pass
+func BenchmarkNewHandlerEager(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var resp Response
+		_ = json.Unmarshal(benchmarkBody, &resp)
+		_ = resp.Success
+	}
+}
+
+func BenchmarkNewHandlerSuccessOnly(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		handler, _ := NewHandler(benchmarkBody)
+		_ = handler.IsSuccess()
+	}
+}
+
+func BenchmarkNewHandlerFullAccess(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		handler, _ := NewHandler(benchmarkBody)
+		_ = handler.GetData()
+	}
+}
+
 func BenchmarkUnmarshalData(b *testing.B) {
 	type TestData struct {
 		ID   int    `json:"id"`
@@ -422,3 +507,464 @@ func BenchmarkUnmarshalData(b *testing.B) {
 		_ = handler.UnmarshalData(&data)
 	}
 }
+
+func TestConcurrentUnmarshalDataErrors(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"id": 1}}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var target []string
+			err := handler.UnmarshalData(&target)
+			var valErr *ValidationError
+			if assert.ErrorAs(t, err, &valErr) {
+				valErr.Context["goroutine"] = i
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var target []string
+	var valErr *ValidationError
+	require.ErrorAs(t, handler.UnmarshalData(&target), &valErr)
+	assert.NotContains(t, valErr.Context, "goroutine")
+}
+
+func TestGetErrorRaw(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {"code": "QUOTA", "message": "quota exceeded", "quota": {"used": 120, "max": 100}}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	var vendorErr struct {
+		Code  string `json:"code"`
+		Quota struct {
+			Used int `json:"used"`
+			Max  int `json:"max"`
+		} `json:"quota"`
+	}
+	raw := handler.GetErrorRaw()
+	require.NotNil(t, raw)
+	require.NoError(t, json.Unmarshal(raw, &vendorErr))
+	assert.Equal(t, "QUOTA", vendorErr.Code)
+	assert.Equal(t, 120, vendorErr.Quota.Used)
+	assert.Equal(t, 100, vendorErr.Quota.Max)
+
+	raw[0] = 'X'
+	assert.Equal(t, byte('{'), handler.GetErrorRaw()[0])
+}
+
+func TestGetErrorRawWithoutError(t *testing.T) {
+	for _, body := range []string{`{"success": true}`, `{"success": true, "error": null}`} {
+		handler, err := NewHandler([]byte(body))
+		require.NoError(t, err)
+		assert.Nil(t, handler.GetErrorRaw())
+		assert.Nil(t, handler.GetError())
+	}
+}
+
+func TestFromHTTPResponseWithTruncatedBody(t *testing.T) {
+	body := `{"success": true, "data": {"id": 1, "name": "te`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "500")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeTruncatedBody, valErr.Code)
+	assert.Equal(t, len(body), valErr.Context["bytes_read"])
+	assert.Equal(t, int64(500), valErr.Context["content_length"])
+}
+
+func TestFromHTTPResponseChunked(t *testing.T) {
+	parts := []string{`{"success": true, `, `"data": {"id": 1, `, `"name": "chunked"}}`}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		w.WriteHeader(http.StatusOK)
+		for _, part := range parts {
+			w.Write([]byte(part))
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	var data struct {
+		Name string `json:"name"`
+	}
+	require.NoError(t, handler.UnmarshalData(&data))
+	assert.Equal(t, "chunked", data.Name)
+}
+
+func TestFromHTTPResponseChunkedCutShort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// Send one complete chunk and then part of a second before closing
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nTransfer-Encoding: chunked\r\n\r\n")
+		buf.WriteString("12\r\n{\"success\": true, \r\n")
+		buf.WriteString("20\r\n\"data\": {\"id\"")
+		buf.Flush()
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeTruncatedBody, valErr.Code)
+	assert.Equal(t, "chunked", valErr.Context["transfer_encoding"])
+	assert.Equal(t, len(`{"success": true, "data": {"id"`), valErr.Context["bytes_read"])
+}
+
+func TestIsTruncated(t *testing.T) {
+	assert.True(t, isTruncated(io.ErrUnexpectedEOF, nil, -1))
+	assert.True(t, isTruncated(fmt.Errorf("read: %w", io.ErrUnexpectedEOF), nil, -1))
+	assert.True(t, isTruncated(nil, []byte("ab"), 4))
+	assert.False(t, isTruncated(nil, []byte("abcd"), 4))
+	assert.False(t, isTruncated(errors.New("connection reset by peer"), []byte("ab"), -1))
+}
+
+func TestFromHTTPResponseTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true, `))
+		w.(http.Flusher).Flush()
+
+		// Stall mid-body until the test finishes
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	start := time.Now()
+	handler, err := FromHTTPResponseTimeout(resp, 50*time.Millisecond)
+	assert.Nil(t, handler)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeReadTimeout, valErr.Code)
+	assert.Equal(t, "50ms", valErr.Context["timeout"])
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFromHTTPResponseTimeoutFastBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseTimeout(resp, time.Second)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+}
+
+func TestIsEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected bool
+	}{
+		{name: "success with data", body: `{"success": true, "data": {"id": 1}}`, expected: false},
+		{name: "success without data", body: `{"success": true}`, expected: true},
+		{name: "error", body: `{"success": false, "error": {"code": "ERR", "message": "msg"}}`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, handler.IsEmpty())
+		})
+	}
+}
+
+func TestNewHandlerWithBOM(t *testing.T) {
+	body := append([]byte{0xEF, 0xBB, 0xBF}, []byte("\n  "+`{"success": true, "meta": {"request_id": "req-bom"}}`)...)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "req-bom", handler.GetRequestID())
+	assert.Equal(t, body, handler.RawBody())
+
+	handler, err = NewHandlerWithOptions(append([]byte{0xEF, 0xBB, 0xBF}, []byte(`{"ok": true}`)...), WithSuccessField("ok"))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+}
+
+func TestRequireRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		shouldErr bool
+	}{
+		{name: "with request id", body: `{"success": true, "meta": {"request_id": "req-123"}}`},
+		{name: "meta without request id", body: `{"success": true, "meta": {"api_version": "v1"}}`, shouldErr: true},
+		{name: "without meta", body: `{"success": true}`, shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+
+			err = handler.RequireRequestID()
+			if !tt.shouldErr {
+				assert.NoError(t, err)
+				return
+			}
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, ErrCodeMissingRequestID, valErr.Code)
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {
+			"code": "INVALID_EMAIL",
+			"message": "Email format is invalid",
+			"field": "email"
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"Error INVALID_EMAIL on email: Email format is invalid",
+		handler.FormatError("Error {code} on {field}: {message}"))
+	assert.Equal(t,
+		"INVALID_EMAIL () {unknown}",
+		handler.FormatError("{code} ({details}) {unknown}"))
+}
+
+func TestFormatErrorNoError(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	assert.Equal(t, "", handler.FormatError("{code}: {message}"))
+
+	var nilHandler *Handler
+	assert.Equal(t, "", nilHandler.FormatError("{code}"))
+}
+
+func TestNewHandlerFromMap(t *testing.T) {
+	handler, err := NewHandlerFromMap(map[string]interface{}{
+		"success": true,
+		"data":    map[string]interface{}{"id": 1, "name": "John"},
+		"meta":    map[string]interface{}{"request_id": "req-map"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "req-map", handler.GetRequestID())
+	assert.JSONEq(t, `{"id": 1, "name": "John"}`, string(handler.GetData()))
+}
+
+func TestNewHandlerFromMapError(t *testing.T) {
+	handler, err := NewHandlerFromMap(map[string]interface{}{
+		"success": false,
+		"error": map[string]interface{}{
+			"code":    "NOT_FOUND",
+			"message": "User not found",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, handler.IsError())
+	assert.Equal(t, "NOT_FOUND | User not found", handler.ErrorString())
+}
+
+func TestNewHandlerFromMapInvalid(t *testing.T) {
+	var valErr *ValidationError
+
+	_, err := NewHandlerFromMap(nil)
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyResponse, valErr.Code)
+
+	_, err = NewHandlerFromMap(map[string]interface{}{"data": make(chan int)})
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONMarshal, valErr.Code)
+
+	_, err = NewHandlerFromMap(map[string]interface{}{"success": "yes"})
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestNewHandlerWhitespaceBody(t *testing.T) {
+	handler, err := NewHandler([]byte(" \n\t "))
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestDataLen(t *testing.T) {
+	for _, body := range []string{
+		`{"success": true, "data": {"id": 1, "name": "test"}}`,
+		`{"success": true, "data": [1, 2, 3]}`,
+		`{"success": true}`,
+	} {
+		handler, err := NewHandler([]byte(body))
+		require.NoError(t, err)
+		assert.Equal(t, len(handler.GetData()), handler.DataLen(), body)
+	}
+
+	var handler *Handler
+	assert.Equal(t, 0, handler.DataLen())
+}
+
+func TestDecodeInto(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"data": {"id": 1},
+		"meta": {"request_id": "req-into", "rate_limit": {"limit": 10, "remaining": 3}}
+	}`)
+
+	resp := &Response{
+		Error:  &ResponseError{Code: "STALE", Message: "left over"},
+		Errors: []*ResponseError{{Code: "STALE"}},
+	}
+	require.NoError(t, DecodeInto(body, resp))
+
+	assert.True(t, resp.Success)
+	assert.JSONEq(t, `{"id": 1}`, string(resp.Data))
+	assert.Nil(t, resp.Error)
+	assert.Nil(t, resp.Errors)
+	require.NotNil(t, resp.Meta)
+	assert.Equal(t, "req-into", resp.Meta.RequestID)
+	assert.Equal(t, 3, resp.Meta.RateLimit.Remaining)
+
+	errorBody := []byte(`{"success": false, "error": {"code": "NOT_FOUND", "message": "missing"}}`)
+	require.NoError(t, DecodeInto(errorBody, resp))
+	assert.False(t, resp.Success)
+	assert.Nil(t, resp.Data)
+	assert.Nil(t, resp.Meta)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "NOT_FOUND", resp.Error.Code)
+}
+
+func TestDecodeIntoInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+		code ErrCode
+	}{
+		{"nil body", nil, ErrCodeEmptyResponse},
+		{"whitespace body", []byte("  "), ErrCodeJSONUnmarshal},
+		{"invalid json", []byte(`{bad`), ErrCodeJSONUnmarshal},
+		{"invalid meta", []byte(`{"success": true, "meta": {"request_id": 5}}`), ErrCodeJSONUnmarshal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{Success: true, Data: json.RawMessage(`1`)}
+			err := DecodeInto(tt.body, resp)
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, tt.code, valErr.Code)
+			assert.Equal(t, Response{}, *resp)
+		})
+	}
+
+	var valErr *ValidationError
+	require.ErrorAs(t, DecodeInto([]byte(`{"success": true}`), nil), &valErr)
+	assert.Equal(t, ErrCodeNilResponse, valErr.Code)
+}
+
+func TestMetaValueAbsent(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, Meta{}, handler.MetaValue())
+	assert.Empty(t, handler.MetaValue().RequestID)
+	assert.Equal(t, RateLimit{}, handler.RateLimitValue())
+	assert.Zero(t, handler.RateLimitValue().Remaining)
+
+	var nilHandler *Handler
+	assert.Equal(t, Meta{}, nilHandler.MetaValue())
+	assert.Equal(t, RateLimit{}, nilHandler.RateLimitValue())
+}
+
+func TestMetaValuePresent(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {
+		"request_id": "req-1",
+		"warnings": ["w"],
+		"rate_limit": {"limit": 10, "remaining": 7, "reset": "2025-01-01T00:00:00Z"}
+	}}`))
+	require.NoError(t, err)
+
+	meta := handler.MetaValue()
+	assert.Equal(t, "req-1", meta.RequestID)
+	assert.Equal(t, 7, handler.RateLimitValue().Remaining)
+
+	meta.Warnings[0] = "changed"
+	meta.RateLimit.Remaining = 0
+	assert.Equal(t, []string{"w"}, handler.GetMeta().Warnings)
+	assert.Equal(t, 7, handler.GetRateLimit().Remaining)
+}
+
+func TestRateLimitValueWithoutRateLimit(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"request_id": "req-1"}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "req-1", handler.MetaValue().RequestID)
+	assert.Equal(t, RateLimit{}, handler.RateLimitValue())
+}
+
+func TestFromHTTPResponseWithOptions(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, nil, `{"ok": true, "data": {"id": 1}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseWithOptions(resp, WithSuccessField("ok"))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, http.StatusOK, handler.StatusCode())
+}
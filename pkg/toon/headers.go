@@ -0,0 +1,216 @@
+package toon
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultIdempotencyKeyHeader is the response header the echoed idempotency
+// key is read from unless WithIdempotencyKeyHeader names another
+const DefaultIdempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKeyHeader makes FromHTTPResponseWithOptions read the echoed
+// idempotency key from the named header instead of DefaultIdempotencyKeyHeader
+func WithIdempotencyKeyHeader(name string) Option {
+	return func(o *handlerOptions) {
+		o.idempotencyKeyHeader = name
+	}
+}
+
+// captureHeaders records HTTP header derived state on the handler
+func (h *Handler) captureHeaders(header http.Header) {
+	if h == nil || header == nil {
+		return
+	}
+
+	now := Now()
+	retryAfter, hasRetryAfter := parseRetryAfter(header.Get("Retry-After"), now)
+	expiresAt, hasExpiry := parseExpiry(header, now)
+	warnings := parseWarnings(header.Values("Warning"))
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keyHeader := h.opts.idempotencyKeyHeader
+	if keyHeader == "" {
+		keyHeader = DefaultIdempotencyKeyHeader
+	}
+	idempotencyKey := strings.TrimSpace(header.Get(keyHeader))
+
+	h.retryAfter = retryAfter
+	h.hasRetryAfter = hasRetryAfter
+	h.expiresAt = expiresAt
+	h.hasExpiry = hasExpiry
+	h.headerWarnings = warnings
+	h.idempotencyKey = idempotencyKey
+}
+
+// parseWarnings extracts the warn-text from HTTP Warning header values of the
+// form `199 - "Miscellaneous warning"`; a header line may hold several
+// comma-separated warnings, and values that do not parse are kept verbatim
+func parseWarnings(values []string) []string {
+	var warnings []string
+	for _, value := range values {
+		for _, warning := range splitOutsideQuotes(value, ',') {
+			warning = strings.TrimSpace(warning)
+			if warning == "" {
+				continue
+			}
+			warnings = append(warnings, warningText(warning))
+		}
+	}
+	return warnings
+}
+
+// warningText returns the unquoted warn-text of a single warning value
+func warningText(warning string) string {
+	start := strings.IndexByte(warning, '"')
+	if start < 0 {
+		return warning
+	}
+
+	text, err := strconv.QuotedPrefix(warning[start:])
+	if err != nil {
+		return warning
+	}
+	unquoted, err := strconv.Unquote(text)
+	if err != nil {
+		return warning
+	}
+	return unquoted
+}
+
+// splitOutsideQuotes splits s at sep, ignoring separators inside double quotes
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && inQuotes:
+			i++
+		case s[i] == '"':
+			inQuotes = !inQuotes
+		case s[i] == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseRetryAfter parses a Retry-After value in delay-seconds or HTTP-date form
+// HTTP dates in the past yield a zero duration
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := date.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// RetryAfterHeader returns the delay from the Retry-After header captured by FromHTTPResponse
+// Returns false if the header was absent or could not be parsed
+func (h *Handler) RetryAfterHeader() (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.retryAfter, h.hasRetryAfter
+}
+
+// parseExpiry computes when a response stops being fresh from its caching headers
+// Cache-Control max-age takes precedence over Expires, as in RFC 9111;
+// no-store and no-cache make the response stale immediately
+func parseExpiry(header http.Header, now time.Time) (time.Time, bool) {
+	if cacheControl := header.Values("Cache-Control"); len(cacheControl) > 0 {
+		for _, directive := range strings.Split(strings.Join(cacheControl, ","), ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-store", "no-cache":
+				return now, true
+			case "max-age":
+				seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+				if err != nil || seconds < 0 {
+					continue
+				}
+				age, _ := strconv.Atoi(header.Get("Age"))
+				if age < 0 {
+					age = 0
+				}
+				return now.Add(time.Duration(seconds-age) * time.Second), true
+			}
+		}
+	}
+
+	expires := header.Get("Expires")
+	if expires == "" {
+		return time.Time{}, false
+	}
+
+	date, err := http.ParseTime(expires)
+	if err != nil {
+		// Invalid dates such as "0" mean already expired
+		return now, true
+	}
+	return date, true
+}
+
+// ExpiresAt returns when the response stops being fresh according to the
+// Cache-Control and Expires headers captured by FromHTTPResponse
+// Returns false if no caching headers were present
+func (h *Handler) ExpiresAt() (time.Time, bool) {
+	if h == nil {
+		return time.Time{}, false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.expiresAt, h.hasExpiry
+}
+
+// IdempotencyKey returns the idempotency key the server echoed in the
+// Idempotency-Key header, or the one set with WithIdempotencyKeyHeader,
+// captured by FromHTTPResponse
+// Comparing it with the key sent shows whether a retry was deduplicated
+// Returns empty string if the server did not echo one
+func (h *Handler) IdempotencyKey() string {
+	if h == nil {
+		return ""
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.idempotencyKey
+}
+
+// IsFresh checks if the response may still be reused from a cache
+// Responses without caching headers are never fresh
+func (h *Handler) IsFresh() bool {
+	expiresAt, ok := h.ExpiresAt()
+	return ok && Now().Before(expiresAt)
+}
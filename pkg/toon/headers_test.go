@@ -0,0 +1,265 @@
+package toon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveWithHeaders returns a server that writes body with the given headers and status
+func serveWithHeaders(t *testing.T, status int, header http.Header, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for key, values := range header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetryAfterHeaderSeconds(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}},
+		`{"success": false, "error": {"code": "RATE_LIMITED", "message": "slow down"}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	wait, ok := handler.RetryAfterHeader()
+	assert.True(t, ok)
+	assert.Equal(t, 120*time.Second, wait)
+	assert.Equal(t, 120*time.Second, handler.RetryAfter())
+}
+
+func TestRetryAfterHeaderHTTPDate(t *testing.T) {
+	date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	server := serveWithHeaders(t, http.StatusServiceUnavailable, http.Header{"Retry-After": {date}},
+		`{"success": false, "error": {"code": "SERVICE_UNAVAILABLE", "message": "maintenance"}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	wait, ok := handler.RetryAfterHeader()
+	assert.True(t, ok)
+	assert.InDelta(t, float64(90*time.Second), float64(wait), float64(2*time.Second))
+}
+
+func TestRetryAfterPrefersHeaderOverReset(t *testing.T) {
+	reset := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	server := serveWithHeaders(t, http.StatusTooManyRequests, http.Header{"Retry-After": {"5"}},
+		`{"success": false, "error": {"code": "RATE_LIMITED", "message": "m"},
+		"meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "`+reset+`"}}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, handler.RetryAfter())
+}
+
+func TestRetryAfterWithoutHeader(t *testing.T) {
+	reset := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "` + reset + `"}}}`))
+	require.NoError(t, err)
+
+	_, ok := handler.RetryAfterHeader()
+	assert.False(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(handler.RetryAfter()), float64(2*time.Second))
+}
+
+func TestParseRetryAfterInvalid(t *testing.T) {
+	now := time.Now()
+	for _, value := range []string{"", "soon", "-5"} {
+		_, ok := parseRetryAfter(value, now)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestExpiresAtFromMaxAge(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{"Cache-Control": {"public, max-age=300"}}, `{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	expiresAt, ok := handler.ExpiresAt()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(300*time.Second), expiresAt, 2*time.Second)
+	assert.True(t, handler.IsFresh())
+}
+
+func TestExpiresAtFromExpiresHeader(t *testing.T) {
+	expires := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	server := serveWithHeaders(t, http.StatusOK, http.Header{"Expires": {expires.Format(http.TimeFormat)}}, `{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	expiresAt, ok := handler.ExpiresAt()
+	require.True(t, ok)
+	assert.True(t, expires.Equal(expiresAt))
+	assert.False(t, handler.IsFresh())
+}
+
+func TestExpiresAtWithoutCachingHeaders(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	_, ok := handler.ExpiresAt()
+	assert.False(t, ok)
+	assert.False(t, handler.IsFresh())
+}
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expiresAt, ok := parseExpiry(http.Header{"Cache-Control": {"max-age=60"}, "Age": {"20"}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(40*time.Second), expiresAt)
+
+	expiresAt, ok = parseExpiry(http.Header{"Cache-Control": {"no-store"}, "Expires": {"Thu, 01 Jan 2099 00:00:00 GMT"}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, now, expiresAt)
+
+	expiresAt, ok = parseExpiry(http.Header{"Expires": {"0"}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, now, expiresAt)
+}
+
+func TestWarningHeadersMergedIntoWarnings(t *testing.T) {
+	header := http.Header{}
+	header.Add("Warning", `110 cache.example.com "Response is Stale"`)
+	header.Add("Warning", `199 - "Heuristic, expiration", 214 proxy "Transformation Applied" "Wed, 21 Oct 2015 07:28:00 GMT"`)
+	server := serveWithHeaders(t, http.StatusOK, header,
+		`{"success": true, "meta": {"warnings": ["field x is deprecated"]}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"field x is deprecated",
+		"Response is Stale",
+		"Heuristic, expiration",
+		"Transformation Applied",
+	}, handler.GetWarnings())
+}
+
+func TestWarningHeadersWithoutMeta(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{"Warning": {"not a standard warning"}},
+		`{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"not a standard warning"}, handler.GetWarnings())
+}
+
+func TestStaleWarningHeaderNotDeprecated(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{"Warning": {`110 - "Response is Stale"`}},
+		`{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Response is Stale"}, handler.GetWarnings())
+	assert.False(t, handler.IsDeprecated())
+}
+
+func TestParseWarnings(t *testing.T) {
+	assert.Nil(t, parseWarnings(nil))
+	assert.Equal(t, []string{`say "hi"`}, parseWarnings([]string{`199 - "say \"hi\""`}))
+	assert.Equal(t, []string{"a", "b"}, parseWarnings([]string{`199 - "a",, 199 - "b"`}))
+}
+
+func TestWarningHeadersSurviveGob(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{"Warning": {`110 - "Response is Stale"`}},
+		`{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	data, err := handler.GobEncode()
+	require.NoError(t, err)
+
+	var decoded Handler
+	require.NoError(t, decoded.GobDecode(data))
+	assert.Equal(t, []string{"Response is Stale"}, decoded.GetWarnings())
+}
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusCreated, http.Header{"Idempotency-Key": {"order-8f14e45f"}},
+		`{"success": true, "data": {"id": 1}}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, "order-8f14e45f", handler.IdempotencyKey())
+
+	data, err := handler.GobEncode()
+	require.NoError(t, err)
+
+	var decoded Handler
+	require.NoError(t, decoded.GobDecode(data))
+	assert.Equal(t, "order-8f14e45f", decoded.IdempotencyKey())
+}
+
+func TestIdempotencyKeyCustomHeader(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, http.Header{
+		"X-Idempotency-Key": {"custom-key"},
+		"Idempotency-Key":   {"ignored"},
+	}, `{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponseWithOptions(resp, WithIdempotencyKeyHeader("X-Idempotency-Key"))
+	require.NoError(t, err)
+	assert.Equal(t, "custom-key", handler.IdempotencyKey())
+}
+
+func TestIdempotencyKeyAbsent(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, nil, `{"success": true}`)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Empty(t, handler.IdempotencyKey())
+
+	var nilHandler *Handler
+	assert.Empty(t, nilHandler.IdempotencyKey())
+}
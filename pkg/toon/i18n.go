@@ -0,0 +1,56 @@
+package toon
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// LocalizedMessage returns the error message for locale from a "messages"
+// object in the error extra, e.g. {"en": "Not found", "fr": "Introuvable"}
+// Locales match case-insensitively with "_" and "-" treated alike, and a
+// regional locale such as "pt-BR" falls back to its base language "pt"
+// Falls back to the default Message when no localized message matches;
+// returns empty string if no error is present
+func (h *Handler) LocalizedMessage(locale string) string {
+	if h == nil {
+		return ""
+	}
+
+	respErr := h.GetError()
+	if respErr == nil {
+		return ""
+	}
+
+	raw, ok := respErr.Extra["messages"]
+	if !ok {
+		return respErr.Message
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return respErr.Message
+	}
+
+	normalized := make(map[string]string, len(messages))
+	for key, message := range messages {
+		if message != "" {
+			normalized[normalizeLocale(key)] = message
+		}
+	}
+
+	want := normalizeLocale(locale)
+	if message, ok := normalized[want]; ok {
+		return message
+	}
+	if base, _, found := strings.Cut(want, "-"); found {
+		if message, ok := normalized[base]; ok {
+			return message
+		}
+	}
+	return respErr.Message
+}
+
+// normalizeLocale lowercases a locale tag and uses "-" as the separator
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
@@ -0,0 +1,64 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizedMessage(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {
+			"code": "NOT_FOUND",
+			"message": "User not found",
+			"messages": {
+				"en": "User not found",
+				"fr": "Utilisateur introuvable",
+				"pt-BR": "Usuário não encontrado",
+				"de": ""
+			}
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"fr", "Utilisateur introuvable"},
+		{"FR", "Utilisateur introuvable"},
+		{"fr-CA", "Utilisateur introuvable"},
+		{"pt_BR", "Usuário não encontrado"},
+		{"pt", "User not found"},
+		{"de", "User not found"},
+		{"ja", "User not found"},
+		{"", "User not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			assert.Equal(t, tt.want, handler.LocalizedMessage(tt.locale))
+		})
+	}
+}
+
+func TestLocalizedMessageWithoutMessages(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "default", "messages": "oops"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "default", handler.LocalizedMessage("fr"))
+
+	handler, err = NewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "default"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "default", handler.LocalizedMessage("fr"))
+
+	handler, err = NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	assert.Equal(t, "", handler.LocalizedMessage("fr"))
+
+	var nilHandler *Handler
+	assert.Equal(t, "", nilHandler.LocalizedMessage("fr"))
+}
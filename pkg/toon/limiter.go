@@ -0,0 +1,58 @@
+package toon
+
+import "golang.org/x/time/rate"
+
+// LimiterSettings converts the server's rate limit into token bucket
+// settings for a client-side limiter such as golang.org/x/time/rate
+//
+// The remaining quota is spread evenly over the time left until reset:
+//
+//	limit = remaining / seconds until reset   (events per second)
+//	burst = 1                                 (no bursting past the even pace)
+//
+// The most constrained limit across all buckets is used
+// An exhausted quota yields limit 0 and burst 0; a rate.Limiter with these
+// settings rejects every event, so Allow reports false and Wait returns an
+// error immediately instead of blocking; use WaitForReset to wait it out
+//
+// Returns false when there is no rate limit, no reset time, or the reset
+// time has already passed, in which case the limiter should be left as is
+// UpdateLimiter applies the settings to a rate.Limiter directly
+func (h *Handler) LimiterSettings() (limit float64, burst int, ok bool) {
+	if h == nil {
+		return 0, 0, false
+	}
+
+	rl := h.MostConstrainedLimit()
+	if rl == nil || rl.Reset.IsZero() {
+		return 0, 0, false
+	}
+
+	window := rl.Reset.Sub(Now())
+	if window <= 0 {
+		return 0, 0, false
+	}
+
+	if rl.Remaining <= 0 {
+		return 0, 0, true
+	}
+	return float64(rl.Remaining) / window.Seconds(), 1, true
+}
+
+// UpdateLimiter applies LimiterSettings to lim
+// Returns false, leaving lim unchanged, when LimiterSettings does
+func (h *Handler) UpdateLimiter(lim *rate.Limiter) bool {
+	if lim == nil {
+		return false
+	}
+
+	limit, burst, ok := h.LimiterSettings()
+	if !ok {
+		return false
+	}
+
+	now := Now()
+	lim.SetLimitAt(now, rate.Limit(limit))
+	lim.SetBurstAt(now, burst)
+	return true
+}
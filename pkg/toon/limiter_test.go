@@ -0,0 +1,117 @@
+package toon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestLimiterSettings(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	fixedNow(t, now)
+
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {
+		"rate_limit": {"limit": 1000, "remaining": 600, "reset": "2025-01-01T10:01:00Z"}
+	}}`))
+	require.NoError(t, err)
+
+	limit, burst, ok := handler.LimiterSettings()
+	require.True(t, ok)
+	assert.InDelta(t, 10.0, limit, 1e-9)
+	assert.Equal(t, 1, burst)
+}
+
+func TestLimiterSettingsChangesWithRemaining(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	fixedNow(t, now)
+
+	before, err := NewHandler([]byte(`{"success": true, "meta": {
+		"rate_limit": {"limit": 100, "remaining": 100, "reset": "2025-01-01T10:00:10Z"}
+	}}`))
+	require.NoError(t, err)
+	after, err := NewHandler([]byte(`{"success": true, "meta": {
+		"rate_limit": {"limit": 100, "remaining": 20, "reset": "2025-01-01T10:00:10Z"}
+	}}`))
+	require.NoError(t, err)
+
+	limitBefore, _, ok := before.LimiterSettings()
+	require.True(t, ok)
+	limitAfter, _, ok := after.LimiterSettings()
+	require.True(t, ok)
+
+	assert.InDelta(t, 10.0, limitBefore, 1e-9)
+	assert.InDelta(t, 2.0, limitAfter, 1e-9)
+}
+
+func TestLimiterSettingsExhausted(t *testing.T) {
+	fixedNow(t, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "RATE_LIMITED", "message": "m"},
+		"meta": {"rate_limit": {"limit": 100, "remaining": 0, "reset": "2025-01-01T10:00:30Z"}}}`))
+	require.NoError(t, err)
+
+	limit, burst, ok := handler.LimiterSettings()
+	assert.True(t, ok)
+	assert.Zero(t, limit)
+	assert.Zero(t, burst)
+}
+
+func TestLimiterSettingsUnavailable(t *testing.T) {
+	fixedNow(t, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	for _, body := range []string{
+		`{"success": true}`,
+		`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 5}}}`,
+		`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 5, "reset": "2025-01-01T09:00:00Z"}}}`,
+	} {
+		handler, err := NewHandler([]byte(body))
+		require.NoError(t, err)
+
+		_, _, ok := handler.LimiterSettings()
+		assert.False(t, ok, body)
+	}
+
+	var nilHandler *Handler
+	_, _, ok := nilHandler.LimiterSettings()
+	assert.False(t, ok)
+}
+
+func TestUpdateLimiter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	fixedNow(t, now)
+
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {
+		"rate_limit": {"limit": 1000, "remaining": 600, "reset": "2025-01-01T10:01:00Z"}
+	}}`))
+	require.NoError(t, err)
+
+	lim := rate.NewLimiter(rate.Inf, 100)
+	require.True(t, handler.UpdateLimiter(lim))
+	assert.InDelta(t, 10.0, float64(lim.Limit()), 1e-9)
+	assert.Equal(t, 1, lim.Burst())
+
+	exhausted, err := NewHandler([]byte(`{"success": true, "meta": {
+		"rate_limit": {"limit": 1000, "remaining": 0, "reset": "2025-01-01T10:01:00Z"}
+	}}`))
+	require.NoError(t, err)
+	require.True(t, exhausted.UpdateLimiter(lim))
+	assert.Zero(t, float64(lim.Limit()))
+	assert.Zero(t, lim.Burst())
+	assert.Error(t, lim.Wait(context.Background()))
+}
+
+func TestUpdateLimiterUnavailable(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	lim := rate.NewLimiter(5, 2)
+	assert.False(t, handler.UpdateLimiter(lim))
+	assert.Equal(t, rate.Limit(5), lim.Limit())
+	assert.Equal(t, 2, lim.Burst())
+
+	assert.False(t, handler.UpdateLimiter(nil))
+}
@@ -0,0 +1,32 @@
+package toon
+
+// SetLocalMeta annotates the handler with client-side metadata, such as the
+// endpoint that produced it, for downstream logging
+// Local meta is kept separate from the server Meta and is never marshaled
+func (h *Handler) SetLocalMeta(key string, value interface{}) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.localMeta == nil {
+		h.localMeta = make(map[string]interface{})
+	}
+	h.localMeta[key] = value
+}
+
+// GetLocalMeta returns the client-side metadata stored under key
+// Returns false if the key was never set
+func (h *Handler) GetLocalMeta(key string) (interface{}, bool) {
+	if h == nil {
+		return nil, false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	value, ok := h.localMeta[key]
+	return value, ok
+}
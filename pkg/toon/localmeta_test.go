@@ -0,0 +1,63 @@
+package toon
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalMeta(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"request_id": "req-1"}}`))
+	require.NoError(t, err)
+
+	_, ok := handler.GetLocalMeta("endpoint")
+	assert.False(t, ok)
+
+	handler.SetLocalMeta("endpoint", "/users")
+	value, ok := handler.GetLocalMeta("endpoint")
+	require.True(t, ok)
+	assert.Equal(t, "/users", value)
+
+	// Server meta and the marshaled body are unaffected
+	assert.Equal(t, "req-1", handler.GetRequestID())
+	body, err := handler.Marshal()
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "endpoint")
+}
+
+func TestLocalMetaConcurrent(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i)
+			handler.SetLocalMeta(key, i)
+			value, ok := handler.GetLocalMeta(key)
+			assert.True(t, ok)
+			assert.Equal(t, i, value)
+			handler.GetLocalMeta("key-0")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < goroutines; i++ {
+		value, ok := handler.GetLocalMeta(fmt.Sprintf("key-%d", i))
+		require.True(t, ok)
+		assert.Equal(t, i, value)
+	}
+}
+
+func TestLocalMetaNilHandler(t *testing.T) {
+	var handler *Handler
+	handler.SetLocalMeta("key", "value")
+	_, ok := handler.GetLocalMeta("key")
+	assert.False(t, ok)
+}
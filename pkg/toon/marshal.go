@@ -0,0 +1,145 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Marshal returns the JSON encoding of the response
+// It reflects any changes made through SetRequestID or SetAPIVersion
+func (h *Handler) Marshal() ([]byte, error) {
+	if h == nil || h.Response() == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+	return h.RawBody(), nil
+}
+
+// SetRequestID overrides the request ID in the response meta
+// It mutates the handler: meta is created if absent and the "meta" key of the
+// raw body is rewritten so that RawBody and Marshal reflect the change
+func (h *Handler) SetRequestID(requestID string) error {
+	return h.updateMeta("request_id", requestID, func(meta *Meta) {
+		meta.RequestID = requestID
+	})
+}
+
+// SetAPIVersion overrides the API version in the response meta
+// It mutates the handler in the same way as SetRequestID
+func (h *Handler) SetAPIVersion(version string) error {
+	return h.updateMeta("api_version", version, func(meta *Meta) {
+		meta.APIVersion = version
+	})
+}
+
+// updateMeta sets key to value in the meta object of the raw body and applies
+// fn to the decoded meta under the write lock
+// Every other key of the body, and of the meta object, is left untouched
+func (h *Handler) updateMeta(key string, value interface{}, fn func(*Meta)) error {
+	if h == nil {
+		return &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.resp == nil {
+		return &ValidationError{
+			Code:    ErrCodeNilResponse,
+			Message: "response is nil",
+		}
+	}
+
+	body, err := setMetaKey(h.body, key, value, h.opts.preserveData)
+	if err != nil {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "failed to update response meta",
+			Err:     err,
+			Context: map[string]interface{}{"key": key},
+		}
+	}
+
+	// Copy so that pointers previously returned by GetMeta are not mutated
+	meta := Meta{}
+	if h.resp.Meta != nil {
+		meta = *h.resp.Meta
+	}
+	fn(&meta)
+	h.resp.Meta = &meta
+	h.body = body
+	return nil
+}
+
+// setMetaKey returns body with key set to value inside its "meta" object
+func setMetaKey(body []byte, key string, value interface{}, preserveData bool) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(stripBOM(body), &fields); err != nil {
+		return nil, err
+	}
+
+	var meta map[string]json.RawMessage
+	if raw, ok := fields["meta"]; ok && !bytes.Equal(raw, []byte("null")) {
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, err
+		}
+	}
+	if meta == nil {
+		meta = make(map[string]json.RawMessage, 1)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	meta[key] = encoded
+
+	if fields["meta"], err = json.Marshal(meta); err != nil {
+		return nil, err
+	}
+	return encodeFields(fields, preserveData)
+}
+
+// encodeFields marshals the top-level fields of a response body
+// When preserveData is set, the "data" value is written verbatim instead of
+// being compacted by encoding/json; every other key is encoded as usual
+func encodeFields(fields map[string]json.RawMessage, preserveData bool) ([]byte, error) {
+	if _, ok := fields["data"]; !preserveData || !ok {
+		return json.Marshal(fields)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		value := fields[key]
+		if key != "data" {
+			if value, err = json.Marshal(value); err != nil {
+				return nil, err
+			}
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
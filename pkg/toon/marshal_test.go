@@ -0,0 +1,122 @@
+package toon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalUnmodified(t *testing.T) {
+	body := `{"success": true, "data": {"id": 1}}`
+	handler, err := NewHandler([]byte(body))
+	require.NoError(t, err)
+
+	out, err := handler.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, body, string(out))
+}
+
+func TestSetRequestIDAndAPIVersion(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-1", "api_version": "v1"}}`))
+	require.NoError(t, err)
+
+	original := handler.GetMeta()
+	require.NoError(t, handler.SetRequestID("req-2"))
+	require.NoError(t, handler.SetAPIVersion("v2"))
+
+	assert.Equal(t, "req-2", handler.GetRequestID())
+	assert.Equal(t, "v2", handler.GetAPIVersion())
+	assert.Equal(t, "req-1", original.RequestID)
+
+	out, err := handler.Marshal()
+	require.NoError(t, err)
+
+	reparsed, err := NewHandler(out)
+	require.NoError(t, err)
+	assert.Equal(t, "req-2", reparsed.GetRequestID())
+	assert.Equal(t, "v2", reparsed.GetAPIVersion())
+	assert.JSONEq(t, `{"id": 1}`, string(reparsed.GetData()))
+	assert.Equal(t, out, handler.RawBody())
+}
+
+func TestSetRequestIDCreatesMeta(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	assert.Nil(t, handler.GetMeta())
+
+	require.NoError(t, handler.SetRequestID("req-new"))
+	assert.Equal(t, "req-new", handler.GetRequestID())
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(handler.RawBody(), &decoded))
+	assert.Equal(t, map[string]interface{}{"request_id": "req-new"}, decoded["meta"])
+}
+
+func TestWithPreserveDataKeepsOriginalBytes(t *testing.T) {
+	data := `{ "amount": 1.50,  "id": 9007199254740993 }`
+	body := []byte(`{"success": true, "data": ` + data + `, "meta": {"request_id": "req-1"}}`)
+
+	handler, err := NewHandlerWithOptions(body, WithPreserveData())
+	require.NoError(t, err)
+	require.NoError(t, handler.SetRequestID("req-2"))
+
+	out, err := handler.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"data":`+data)
+
+	reparsed, err := NewHandler(out)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(reparsed.GetData()))
+	assert.Equal(t, "req-2", reparsed.GetRequestID())
+
+	handler, err = NewHandler(body)
+	require.NoError(t, err)
+	require.NoError(t, handler.SetRequestID("req-2"))
+
+	out, err = handler.Marshal()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), data)
+}
+
+func TestSetRequestIDKeepsUnknownKeys(t *testing.T) {
+	body := `{"ok": true, "data": {"id": 1}, "trace": "abc", "meta": {"timestamp": "2025-06-01T12:00:00+02:00", "region": "eu"}}`
+	handler, err := NewHandlerWithOptions([]byte(body), WithSuccessField("ok"))
+	require.NoError(t, err)
+
+	require.NoError(t, handler.SetRequestID("req-1"))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(handler.RawBody(), &decoded))
+	assert.Equal(t, true, decoded["ok"])
+	assert.Equal(t, "abc", decoded["trace"])
+	assert.NotContains(t, decoded, "success")
+	assert.Equal(t, map[string]interface{}{
+		"timestamp":  "2025-06-01T12:00:00+02:00",
+		"region":     "eu",
+		"request_id": "req-1",
+	}, decoded["meta"])
+}
+
+func TestSetRequestIDNilHandler(t *testing.T) {
+	var handler *Handler
+	err := handler.SetRequestID("req-1")
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+}
+
+func TestWithPreserveDataKeepsOtherKeys(t *testing.T) {
+	data := `[ 1.50, 2.00 ]`
+	body := []byte(`{"ok": true, "data": ` + data + `, "trace": {"id": "abc"}}`)
+
+	handler, err := NewHandlerWithOptions(body, WithPreserveData(), WithSuccessField("ok"))
+	require.NoError(t, err)
+	require.NoError(t, handler.SetAPIVersion("v2"))
+
+	out := handler.RawBody()
+	assert.Contains(t, string(out), `"data":`+data)
+	assert.JSONEq(t, `{"ok": true, "data": [1.50, 2.00], "trace": {"id": "abc"}, "meta": {"api_version": "v2"}}`, string(out))
+}
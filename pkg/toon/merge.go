@@ -0,0 +1,85 @@
+package toon
+
+import (
+	"strings"
+)
+
+// MergeMeta combines the metadata of several handlers into one Meta, e.g.
+// for the meta section of an aggregate response
+// The merge rules are:
+//   - RateLimit is the most restrictive one: fewest remaining requests, ties
+//     going to the later reset
+//   - RateLimits keeps every bucket name, each with its most restrictive limit
+//   - Timestamp is the latest timestamp
+//   - Sunset is the earliest sunset
+//   - Warnings, including Warning headers, are deduplicated in first-seen order
+//   - APIVersion lists the distinct versions in first-seen order joined by ", "
+//   - RequestID and Pagination describe a single response and are left empty
+//   - Extra fields are vendor-specific and are not merged
+//
+// Nil handlers are skipped; returns nil when no handler carries any metadata
+func MergeMeta(handlers ...*Handler) *Meta {
+	merged := &Meta{}
+	found := false
+	seenWarnings := make(map[string]bool)
+	var versions []string
+
+	for _, h := range handlers {
+		if h == nil {
+			continue
+		}
+
+		for _, warning := range h.GetWarnings() {
+			found = true
+			if !seenWarnings[warning] {
+				seenWarnings[warning] = true
+				merged.Warnings = append(merged.Warnings, warning)
+			}
+		}
+
+		meta := h.GetMeta()
+		if meta == nil {
+			continue
+		}
+		found = true
+
+		if meta.Timestamp.After(merged.Timestamp) {
+			merged.Timestamp = meta.Timestamp
+		}
+		if meta.Sunset != nil && (merged.Sunset == nil || meta.Sunset.Before(*merged.Sunset)) {
+			sunset := *meta.Sunset
+			merged.Sunset = &sunset
+		}
+		if meta.APIVersion != "" && !containsString(versions, meta.APIVersion) {
+			versions = append(versions, meta.APIVersion)
+		}
+		if meta.RateLimit != nil && (merged.RateLimit == nil || moreRestrictive(*meta.RateLimit, *merged.RateLimit)) {
+			rl := *meta.RateLimit
+			merged.RateLimit = &rl
+		}
+		for name, rl := range meta.RateLimits {
+			if merged.RateLimits == nil {
+				merged.RateLimits = make(map[string]RateLimit)
+			}
+			if current, ok := merged.RateLimits[name]; !ok || moreRestrictive(rl, current) {
+				merged.RateLimits[name] = rl
+			}
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	merged.APIVersion = strings.Join(versions, ", ")
+	return merged
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
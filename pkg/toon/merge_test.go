@@ -0,0 +1,84 @@
+package toon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMeta(t *testing.T) {
+	first, err := NewHandler([]byte(`{"success": true, "meta": {
+		"timestamp": "2025-01-01T10:00:00Z",
+		"api_version": "v1",
+		"rate_limit": {"limit": 100, "remaining": 40, "reset": "2025-01-01T11:00:00Z"},
+		"rate_limits": {"search": {"limit": 10, "remaining": 5, "reset": "2025-01-01T10:01:00Z"}},
+		"warnings": ["a", "b"],
+		"sunset": "2026-01-01T00:00:00Z"
+	}}`))
+	require.NoError(t, err)
+
+	second, err := NewHandler([]byte(`{"success": true, "meta": {
+		"timestamp": "2025-01-01T10:05:00Z",
+		"api_version": "v2",
+		"rate_limit": {"limit": 50, "remaining": 3, "reset": "2025-01-01T10:30:00Z"},
+		"rate_limits": {"search": {"limit": 10, "remaining": 8, "reset": "2025-01-01T10:02:00Z"},
+			"write": {"limit": 5, "remaining": 1, "reset": "2025-01-01T10:10:00Z"}},
+		"warnings": ["b", "c"],
+		"sunset": "2025-06-01T00:00:00Z"
+	}}`))
+	require.NoError(t, err)
+
+	third, err := NewHandler([]byte(`{"success": true, "meta": {
+		"timestamp": "2025-01-01T09:00:00Z",
+		"api_version": "v1"
+	}}`))
+	require.NoError(t, err)
+
+	merged := MergeMeta(first, nil, second, third)
+	require.NotNil(t, merged)
+
+	assert.Equal(t, time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC), merged.Timestamp.UTC())
+	assert.Equal(t, "v1, v2", merged.APIVersion)
+	assert.Equal(t, []string{"a", "b", "c"}, merged.Warnings)
+	require.NotNil(t, merged.Sunset)
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), merged.Sunset.UTC())
+
+	require.NotNil(t, merged.RateLimit)
+	assert.Equal(t, 50, merged.RateLimit.Limit)
+	assert.Equal(t, 3, merged.RateLimit.Remaining)
+
+	assert.Equal(t, 5, merged.RateLimits["search"].Remaining)
+	assert.Equal(t, 1, merged.RateLimits["write"].Remaining)
+	assert.Empty(t, merged.RequestID)
+	assert.Nil(t, merged.Pagination)
+}
+
+func TestMergeMetaRateLimitTieGoesToLaterReset(t *testing.T) {
+	early, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "2025-01-01T10:00:00Z"}}}`))
+	require.NoError(t, err)
+	late, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "2025-01-01T12:00:00Z"}}}`))
+	require.NoError(t, err)
+
+	merged := MergeMeta(early, late)
+	require.NotNil(t, merged.RateLimit)
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), merged.RateLimit.Reset.UTC())
+}
+
+func TestMergeMetaDoesNotAliasHandlerMeta(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 4, "reset": "2025-01-01T10:00:00Z"}}}`))
+	require.NoError(t, err)
+
+	merged := MergeMeta(handler)
+	merged.RateLimit.Remaining = 0
+	assert.Equal(t, 4, handler.GetRateLimit().Remaining)
+}
+
+func TestMergeMetaWithoutMeta(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	assert.Nil(t, MergeMeta())
+	assert.Nil(t, MergeMeta(nil, handler))
+}
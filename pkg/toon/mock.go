@@ -0,0 +1,49 @@
+package toon
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// MockServer returns an http.Handler serving canned Toon responses by URL path
+// The status code of each response is derived from its body via HTTPStatus;
+// bodies that cannot be parsed are served with 500
+// Unknown paths are answered with a NOT_FOUND Toon error and 404
+func MockServer(responses map[string][]byte) http.Handler {
+	routes := make(map[string]*Handler, len(responses))
+	raw := make(map[string][]byte, len(responses))
+	for path, body := range responses {
+		copied := make([]byte, len(body))
+		copy(copied, body)
+		raw[path] = copied
+
+		if handler, err := NewHandler(copied); err == nil {
+			routes[path] = handler
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler, ok := routes[r.URL.Path]; ok {
+			_, _ = handler.WriteTo(w)
+			return
+		}
+
+		if body, ok := raw[r.URL.Path]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write(body)
+			return
+		}
+
+		body, _ := json.Marshal(&Response{
+			Success: false,
+			Error: &ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "no mock response for " + r.URL.Path,
+			},
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write(body)
+	})
+}
@@ -0,0 +1,45 @@
+package toon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockServer(t *testing.T) {
+	server := httptest.NewServer(MockServer(map[string][]byte{
+		"/users/1": []byte(`{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-1"}}`),
+		"/users/2": []byte(`{"success": false, "error": {"code": "UNAUTHORIZED", "message": "login required"}}`),
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		path    string
+		status  int
+		success bool
+		code    string
+	}{
+		{path: "/users/1", status: http.StatusOK, success: true},
+		{path: "/users/2", status: http.StatusUnauthorized, success: false, code: "UNAUTHORIZED"},
+		{path: "/unknown", status: http.StatusNotFound, success: false, code: "NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			handler, err := FromHTTPResponse(resp)
+			require.NoError(t, err)
+			assert.Equal(t, tt.success, handler.IsSuccess())
+			if tt.code != "" {
+				require.NotNil(t, handler.GetError())
+				assert.Equal(t, tt.code, handler.GetError().Code)
+			}
+		})
+	}
+}
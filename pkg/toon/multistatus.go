@@ -0,0 +1,55 @@
+package toon
+
+import "encoding/json"
+
+// SubResults parses the results array of a multi-status (207) batch response,
+// where each element is itself a {success, data/error} envelope
+// Sub-handlers are created with default options, since options such as
+// WithRootPath describe the outer envelope; a sub-result that fails to parse
+// is reported with its index in the error context
+// Returns nil if the response has no results array
+func (h *Handler) SubResults() ([]*Handler, error) {
+	if h == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	h.mu.RLock()
+	var results []json.RawMessage
+	if h.resp != nil {
+		results = h.resp.Results
+	}
+	h.mu.RUnlock()
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	handlers := make([]*Handler, 0, len(results))
+	for i, raw := range results {
+		sub, err := NewHandler(append([]byte(nil), raw...))
+		if err != nil {
+			return nil, withContext(err, map[string]interface{}{
+				"result_index": i,
+			})
+		}
+		handlers = append(handlers, sub)
+	}
+	return handlers, nil
+}
+
+// allSucceeded reports whether every raw sub-result has "success": true
+// Sub-results that cannot be read count as failures
+func allSucceeded(results []json.RawMessage) bool {
+	for _, raw := range results {
+		var sub struct {
+			Success bool `json:"success"`
+		}
+		if err := json.Unmarshal(raw, &sub); err != nil || !sub.Success {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package toon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multiStatusBody = `{
+	"success": true,
+	"results": [
+		{"success": true, "data": {"id": 1}},
+		{"success": false, "error": {"code": "NOT_FOUND", "message": "item 2 not found"}},
+		{"success": true, "data": {"id": 3}}
+	]
+}`
+
+func TestSubResultsMixed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(multiStatusBody))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMultiStatus, handler.StatusCode())
+	assert.False(t, handler.IsSuccess())
+	assert.NoError(t, handler.Validate())
+
+	subs, err := handler.SubResults()
+	require.NoError(t, err)
+	require.Len(t, subs, 3)
+
+	assert.True(t, subs[0].IsSuccess())
+	assert.JSONEq(t, `{"id": 1}`, string(subs[0].GetData()))
+
+	assert.True(t, subs[1].IsError())
+	assert.Equal(t, "NOT_FOUND", subs[1].GetError().Code)
+
+	assert.True(t, subs[2].IsSuccess())
+	assert.JSONEq(t, `{"id": 3}`, string(subs[2].GetData()))
+}
+
+func TestSubResultsAllSucceeded(t *testing.T) {
+	body := []byte(`{"results": [{"success": true, "data": 1}, {"success": true, "data": 2}]}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+
+	subs, err := handler.SubResults()
+	require.NoError(t, err)
+	assert.Len(t, subs, 2)
+}
+
+func TestSubResultsExplicitSuccessWins(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "results": [{"success": true, "data": 1}]}`))
+	require.NoError(t, err)
+	assert.False(t, handler.IsSuccess())
+
+	handler, err = NewHandler([]byte(`{"success": true, "results": [{"success": false, "error": {"code": "X", "message": "m"}}]}`))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+}
+
+func TestSubResultsIgnoreRootPath(t *testing.T) {
+	body := []byte(`{"payload": {"results": [{"success": true, "data": {"id": 1}}]}}`)
+	handler, err := NewHandlerWithOptions(body, WithRootPath("payload"))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+
+	subs, err := handler.SubResults()
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.JSONEq(t, `{"id": 1}`, string(subs[0].GetData()))
+}
+
+func TestSubResultsAbsent(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	subs, err := handler.SubResults()
+	require.NoError(t, err)
+	assert.Nil(t, subs)
+}
+
+func TestSubResultsInvalid(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"results": [{"success": true}, "oops"]}`))
+	require.NoError(t, err)
+	assert.False(t, handler.IsSuccess())
+
+	_, err = handler.SubResults()
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+	assert.Equal(t, 1, valErr.Context["result_index"])
+
+	var nilHandler *Handler
+	_, err = nilHandler.SubResults()
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+}
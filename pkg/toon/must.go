@@ -0,0 +1,20 @@
+package toon
+
+// MustNewHandler is like NewHandler but panics if the body cannot be parsed
+// It trades safety for brevity and is intended only for main packages,
+// scripts and tests; library code should use NewHandler
+func MustNewHandler(body []byte) *Handler {
+	handler, err := NewHandler(body)
+	if err != nil {
+		panic(err)
+	}
+	return handler
+}
+
+// MustData is like UnmarshalData but panics if the data cannot be decoded into v
+// It is intended only for main packages, scripts and tests
+func (h *Handler) MustData(v interface{}) {
+	if err := h.UnmarshalData(v); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,53 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recoverValidationError runs fn and returns the ValidationError it panicked with
+func recoverValidationError(t *testing.T, fn func()) (valErr *ValidationError) {
+	t.Helper()
+
+	defer func() {
+		r := recover()
+		require.NotNil(t, r, "expected panic")
+
+		err, ok := r.(error)
+		require.True(t, ok)
+		require.ErrorAs(t, err, &valErr)
+	}()
+
+	fn()
+	return nil
+}
+
+func TestMustNewHandler(t *testing.T) {
+	handler := MustNewHandler([]byte(`{"success": true}`))
+	assert.True(t, handler.IsSuccess())
+
+	valErr := recoverValidationError(t, func() {
+		MustNewHandler([]byte(`{invalid json}`))
+	})
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestMustData(t *testing.T) {
+	handler := MustNewHandler([]byte(`{"success": true, "data": {"id": 7}}`))
+
+	var data struct {
+		ID int `json:"id"`
+	}
+	assert.NotPanics(t, func() {
+		handler.MustData(&data)
+	})
+	assert.Equal(t, 7, data.ID)
+
+	valErr := recoverValidationError(t, func() {
+		var target []string
+		handler.MustData(&target)
+	})
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
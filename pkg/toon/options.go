@@ -0,0 +1,237 @@
+package toon
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Option configures how NewHandlerWithOptions parses a response
+type Option func(*handlerOptions)
+
+// handlerOptions holds the parsing configuration for a Handler
+type handlerOptions struct {
+	// successField names the field that signals success; empty means "success"
+	successField string
+	// successValue is the string value of successField that signals success
+	// When empty, successField is read as a boolean
+	successValue string
+	// preserveData keeps the original data bytes when the body is re-encoded
+	preserveData bool
+	// unwrapStringData decodes data sent as a string containing JSON
+	unwrapStringData bool
+	// decodeCache memoizes UnmarshalData results by target type
+	decodeCache bool
+	// dataTransforms are applied in order to the data before it is decoded
+	dataTransforms []DataTransform
+	// rootPath is the dotted path of the object holding the envelope
+	rootPath string
+	// detectHTML reports HTML bodies read from an HTTP response as PROXY_ERROR
+	detectHTML bool
+	// idempotencyKeyHeader names the header holding the echoed idempotency key
+	idempotencyKeyHeader string
+}
+
+// DataTransform rewrites the raw data before it is decoded
+type DataTransform func(json.RawMessage) (json.RawMessage, error)
+
+// WithSuccessField configures a boolean field other than "success" to signal success
+// For example, WithSuccessField("ok") parses envelopes like {"ok": true, ...}
+func WithSuccessField(name string) Option {
+	return func(o *handlerOptions) {
+		o.successField = name
+		o.successValue = ""
+	}
+}
+
+// WithSuccessValue configures a string field whose value signals success
+// For example, WithSuccessValue("status", "ok") parses envelopes like {"status": "ok", ...}
+func WithSuccessValue(field, value string) Option {
+	return func(o *handlerOptions) {
+		o.successField = field
+		o.successValue = value
+	}
+}
+
+// WithPreserveData keeps the exact original data bytes when the response is
+// re-encoded after a mutation, so Marshal reproduces the data section
+// byte-for-byte; this matters for signature verification over the payload
+func WithPreserveData() Option {
+	return func(o *handlerOptions) {
+		o.preserveData = true
+	}
+}
+
+// WithDataTransform registers a transform applied to the data before it is
+// decoded, e.g. to unwrap an extra {"result": ...} layer
+// It applies to UnmarshalData and its variants, DataDecoder and StreamDataItems
+// Transforms run in registration order; GetData still returns the original data
+func WithDataTransform(fn DataTransform) Option {
+	return func(o *handlerOptions) {
+		if fn != nil {
+			o.dataTransforms = append(o.dataTransforms, fn)
+		}
+	}
+}
+
+// WithUnwrapStringData makes the decoding accessors transparently decode data that a
+// gateway sent as a JSON string containing JSON, e.g. "data": "{\"id\": 1}"
+// Only strings holding a valid JSON object or array are unwrapped
+// The unwrap runs before any transforms registered with WithDataTransform
+func WithUnwrapStringData() Option {
+	return func(o *handlerOptions) {
+		o.unwrapStringData = true
+	}
+}
+
+// WithDecodeCache memoizes UnmarshalData results by target type, so repeated
+// decodes into the same type copy a cached value instead of parsing again
+// It assumes the data is immutable for the handler's lifetime; every call
+// receives a deep copy, so callers may freely modify what they decode
+// Unlike a plain decode, the whole target value is replaced, on a cache miss
+// as well as on a hit, so fields preset by the caller are never kept
+func WithDecodeCache() Option {
+	return func(o *handlerOptions) {
+		o.decodeCache = true
+	}
+}
+
+// NewHandlerWithOptions creates a new Handler from raw bytes using the given options
+// With no options it behaves exactly like NewHandler
+func NewHandlerWithOptions(body []byte, opts ...Option) (*Handler, error) {
+	var o handlerOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return newHandlerWithOptions(body, o)
+}
+
+// newHandlerWithOptions implements NewHandlerWithOptions for resolved options
+func newHandlerWithOptions(body []byte, o handlerOptions) (*Handler, error) {
+	if o.rootPath != "" {
+		root, err := navigateRootPath(body, o.rootPath)
+		if err != nil {
+			return nil, err
+		}
+		body = root
+	}
+
+	handler, err := NewHandler(body)
+	if err != nil {
+		return nil, err
+	}
+	handler.opts = o
+
+	if o.successField != "" {
+		success, err := readSuccessField(body, o)
+		if err != nil {
+			return nil, err
+		}
+		handler.resp.Success = success
+	}
+
+	return handler, nil
+}
+
+// readSuccessField reads the configured success field from the top-level object
+// A missing field is treated as not successful
+func readSuccessField(body []byte, o handlerOptions) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(stripBOM(body), &fields); err != nil {
+		return false, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal response body",
+			Err:     err,
+			Context: map[string]interface{}{
+				"body_size": len(body),
+			},
+		}
+	}
+
+	raw, ok := fields[o.successField]
+	if !ok {
+		return false, nil
+	}
+
+	if o.successValue == "" {
+		var success bool
+		if err := json.Unmarshal(raw, &success); err != nil {
+			return false, &ValidationError{
+				Code:    ErrCodeJSONUnmarshal,
+				Message: fmt.Sprintf("success field %q is not a boolean", o.successField),
+				Err:     err,
+				Context: map[string]interface{}{
+					"field": o.successField,
+				},
+			}
+		}
+		return success, nil
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return false, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: fmt.Sprintf("success field %q is not a string", o.successField),
+			Err:     err,
+			Context: map[string]interface{}{
+				"field": o.successField,
+			},
+		}
+	}
+	return value == o.successValue, nil
+}
+
+// options returns the options the handler was created with
+func (h *Handler) options() handlerOptions {
+	if h == nil {
+		return handlerOptions{}
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.opts
+}
+
+// decodableData returns a copy of the data with the registered transforms
+// applied; every accessor that decodes the data reads it through here
+// Returns ValidationError if data is empty or a transform fails
+func (h *Handler) decodableData() (json.RawMessage, error) {
+	data := h.GetData()
+	if len(data) == 0 {
+		return nil, &ValidationError{
+			Code:    ErrCodeEmptyData,
+			Message: "response data is empty",
+		}
+	}
+	return h.transformData(data)
+}
+
+// transformData applies the registered data transforms to data
+func (h *Handler) transformData(data json.RawMessage) (json.RawMessage, error) {
+	opts := h.options()
+	if opts.unwrapStringData {
+		if inner, ok := unwrapStringData(data); ok {
+			data = inner
+		}
+	}
+
+	for i, fn := range opts.dataTransforms {
+		transformed, err := fn(data)
+		if err != nil {
+			return nil, &ValidationError{
+				Code:    ErrCodeDataTransform,
+				Message: "data transform failed",
+				Err:     err,
+				Context: map[string]interface{}{
+					"transform_index": i,
+					"data_size":       len(data),
+				},
+			}
+		}
+		data = transformed
+	}
+	return data, nil
+}
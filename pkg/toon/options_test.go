@@ -0,0 +1,141 @@
+package toon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHandlerWithOptionsDefault(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+}
+
+func TestWithSuccessField(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{"ok": true, "data": {"id": 1}}`), WithSuccessField("ok"))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+	assert.NotNil(t, handler.GetData())
+
+	handler, err = NewHandlerWithOptions(
+		[]byte(`{"ok": false, "error": {"code": "ERR", "message": "msg"}}`),
+		WithSuccessField("ok"),
+	)
+	require.NoError(t, err)
+	assert.False(t, handler.IsSuccess())
+	assert.True(t, handler.IsError())
+
+	_, err = NewHandlerWithOptions([]byte(`{"ok": "yes"}`), WithSuccessField("ok"))
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestWithSuccessValue(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{"status": "ok", "data": {"id": 1}}`), WithSuccessValue("status", "ok"))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+
+	handler, err = NewHandlerWithOptions(
+		[]byte(`{"status": "error", "error": {"code": "ERR", "message": "msg"}}`),
+		WithSuccessValue("status", "ok"),
+	)
+	require.NoError(t, err)
+	assert.False(t, handler.IsSuccess())
+	assert.True(t, handler.IsError())
+}
+
+// unwrapKey returns a transform that replaces an object with the value of key
+func unwrapKey(key string) DataTransform {
+	return func(data json.RawMessage) (json.RawMessage, error) {
+		var wrapper map[string]json.RawMessage
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, err
+		}
+		inner, ok := wrapper[key]
+		if !ok {
+			return nil, fmt.Errorf("missing %q key", key)
+		}
+		return inner, nil
+	}
+}
+
+func TestWithDataTransform(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"result": {"id": 7, "name": "wrapped"}}}`)
+
+	handler, err := NewHandlerWithOptions(body, WithDataTransform(unwrapKey("result")))
+	require.NoError(t, err)
+
+	var data struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	require.NoError(t, handler.UnmarshalData(&data))
+	assert.Equal(t, 7, data.ID)
+	assert.Equal(t, "wrapped", data.Name)
+	assert.JSONEq(t, `{"result": {"id": 7, "name": "wrapped"}}`, string(handler.GetData()))
+}
+
+func TestWithDataTransformAllAccessors(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"result": [{"id": 1}, {"id": 2}]}}`)
+
+	handler, err := NewHandlerWithOptions(body, WithDataTransform(unwrapKey("result")))
+	require.NoError(t, err)
+
+	type item struct {
+		ID int `json:"id"`
+	}
+	want := []item{{ID: 1}, {ID: 2}}
+
+	var pooled []item
+	require.NoError(t, handler.UnmarshalDataPooled(&pooled))
+	assert.Equal(t, want, pooled)
+
+	var strict []item
+	require.NoError(t, handler.UnmarshalDataStrict(&strict))
+	assert.Equal(t, want, strict)
+
+	var sliced []item
+	n, err := handler.UnmarshalDataSlice(&sliced)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, want, sliced)
+
+	var numbers []map[string]interface{}
+	require.NoError(t, handler.UnmarshalDataNumber(&numbers))
+	assert.Equal(t, json.Number("2"), numbers[1]["id"])
+
+	dec, err := handler.DataDecoder()
+	require.NoError(t, err)
+	tok, err := dec.Token()
+	require.NoError(t, err)
+	assert.Equal(t, json.Delim('['), tok)
+
+	items, errs := handler.StreamDataItems(context.Background())
+	var streamed []string
+	for raw := range items {
+		streamed = append(streamed, string(raw))
+	}
+	require.NoError(t, <-errs)
+	assert.Equal(t, []string{`{"id": 1}`, `{"id": 2}`}, streamed)
+}
+
+func TestWithDataTransformError(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"other": 1}}`)
+
+	handler, err := NewHandlerWithOptions(body, WithDataTransform(unwrapKey("result")))
+	require.NoError(t, err)
+
+	var data map[string]interface{}
+	err = handler.UnmarshalData(&data)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeDataTransform, valErr.Code)
+	assert.Contains(t, err.Error(), `missing "result" key`)
+}
@@ -0,0 +1,106 @@
+package toon
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultCursorParam is the query parameter used by NextPageRequest
+const DefaultCursorParam = "cursor"
+
+// GetPagination safely returns pagination information if available
+func (h *Handler) GetPagination() *Pagination {
+	meta := h.GetMeta()
+	if meta == nil {
+		return nil
+	}
+	return meta.Pagination
+}
+
+// NextCursor safely returns the cursor of the next page
+// Returns empty string if there is no next page
+func (h *Handler) NextCursor() string {
+	p := h.GetPagination()
+	if p == nil {
+		return ""
+	}
+	return p.NextCursor
+}
+
+// HasNextPage checks if the response indicates another page is available
+func (h *Handler) HasNextPage() bool {
+	return h.NextCursor() != ""
+}
+
+// NextPageRequest clones base and sets the cursor query parameter to the next cursor
+// Returns nil if there is no next page
+// Returns ValidationError if the response carries no pagination information
+func (h *Handler) NextPageRequest(base *http.Request) (*http.Request, error) {
+	return h.NextPageRequestParam(base, DefaultCursorParam)
+}
+
+// NextPageRequestParam is like NextPageRequest but uses the named query parameter
+func (h *Handler) NextPageRequestParam(base *http.Request, param string) (*http.Request, error) {
+	if base == nil || base.URL == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "base request is nil",
+		}
+	}
+
+	p := h.GetPagination()
+	if p == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "response has no pagination information",
+			Context: map[string]interface{}{
+				"request_id": h.GetRequestID(),
+			},
+		}
+	}
+
+	if p.NextCursor == "" {
+		return nil, nil
+	}
+
+	next := base.Clone(base.Context())
+	query := next.URL.Query()
+	query.Set(param, p.NextCursor)
+	next.URL.RawQuery = query.Encode()
+	return next, nil
+}
+
+// Paginate fetches pages starting at firstReq and calls yield for each handler
+// It follows next-page cursors until there is no next page or yield returns an error
+// Between pages it waits for the rate limit to reset when the quota is exhausted
+func Paginate(ctx context.Context, client *http.Client, firstReq *http.Request, yield func(*Handler) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req := firstReq
+	for req != nil {
+		handler, _, err := DoTimed(ctx, client, req)
+		if err != nil {
+			return err
+		}
+
+		if err := yield(handler); err != nil {
+			return err
+		}
+
+		if !handler.HasNextPage() {
+			return nil
+		}
+
+		if err := handler.WaitForReset(ctx); err != nil {
+			return err
+		}
+
+		req, err = handler.NextPageRequest(req)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
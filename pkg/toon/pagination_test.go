@@ -0,0 +1,142 @@
+package toon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextPageRequest(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"data": [{"id": 1}],
+		"meta": {"pagination": {"next_cursor": "abc123", "has_more": true}}
+	}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.True(t, handler.HasNextPage())
+
+	base, err := http.NewRequest(http.MethodGet, "http://example.com/items?limit=10", nil)
+	require.NoError(t, err)
+	base.Header.Set("Authorization", "Bearer token")
+
+	next, err := handler.NextPageRequest(base)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.NotSame(t, base, next)
+	assert.Equal(t, "abc123", next.URL.Query().Get("cursor"))
+	assert.Equal(t, "10", next.URL.Query().Get("limit"))
+	assert.Equal(t, "Bearer token", next.Header.Get("Authorization"))
+	assert.Empty(t, base.URL.Query().Get("cursor"))
+}
+
+func TestNextPageRequestCustomParam(t *testing.T) {
+	body := []byte(`{"success": true, "meta": {"pagination": {"next_cursor": "abc123"}}}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	base, err := http.NewRequest(http.MethodGet, "http://example.com/items", nil)
+	require.NoError(t, err)
+
+	next, err := handler.NextPageRequestParam(base, "page_token")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", next.URL.Query().Get("page_token"))
+}
+
+func TestNextPageRequestLastPage(t *testing.T) {
+	body := []byte(`{"success": true, "meta": {"pagination": {"has_more": false}}}`)
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	base, err := http.NewRequest(http.MethodGet, "http://example.com/items", nil)
+	require.NoError(t, err)
+
+	next, err := handler.NextPageRequest(base)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+}
+
+func TestNextPageRequestWithoutPagination(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	base, err := http.NewRequest(http.MethodGet, "http://example.com/items", nil)
+	require.NoError(t, err)
+
+	next, err := handler.NextPageRequest(base)
+	assert.Nil(t, next)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+}
+
+func TestPaginate(t *testing.T) {
+	reset := float64(time.Now().Add(100*time.Millisecond).UnixNano()) / float64(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprintf(w, `{
+				"success": true,
+				"data": [{"id": 1}, {"id": 2}],
+				"meta": {
+					"pagination": {"next_cursor": "page2", "has_more": true},
+					"rate_limit": {"limit": 10, "remaining": 0, "reset": %f}
+				}
+			}`, reset)
+		case "page2":
+			w.Write([]byte(`{"success": true, "data": [{"id": 3}], "meta": {"pagination": {"has_more": false}}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	var ids []int
+	pages := 0
+	err = Paginate(context.Background(), server.Client(), req, func(h *Handler) error {
+		pages++
+		var items []struct {
+			ID int `json:"id"`
+		}
+		if err := h.UnmarshalData(&items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestPaginateStopsOnYieldError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success": true, "meta": {"pagination": {"next_cursor": "next"}}}`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	stop := errors.New("stop")
+	pages := 0
+	err = Paginate(context.Background(), server.Client(), req, func(h *Handler) error {
+		pages++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, pages)
+}
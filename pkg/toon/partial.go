@@ -0,0 +1,73 @@
+package toon
+
+// IsPartialSuccess checks if a successful response reports per-item failures
+// It returns true when success is true and the errors array is populated
+func (h *Handler) IsPartialSuccess() bool {
+	return h.IsSuccess() && len(h.PartialErrors()) > 0
+}
+
+// PartialErrors safely returns a copy of the per-item errors from the response
+// Returns nil if the errors array is absent or empty
+func (h *Handler) PartialErrors() []*ResponseError {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil || len(h.resp.Errors) == 0 {
+		return nil
+	}
+
+	errs := make([]*ResponseError, 0, len(h.resp.Errors))
+	for _, e := range h.resp.Errors {
+		if e != nil {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// FieldErrors returns a map from error field to message for form validation UIs
+// It includes the top-level error and any per-item errors; errors without a
+// field are omitted and the first message for a field wins
+// Returns an empty map if no field errors are present
+func (h *Handler) FieldErrors() map[string]string {
+	fields := make(map[string]string)
+
+	errs := append([]*ResponseError{h.GetError()}, h.PartialErrors()...)
+	for _, e := range errs {
+		if e == nil || e.Field == "" {
+			continue
+		}
+		if _, exists := fields[e.Field]; !exists {
+			fields[e.Field] = e.Message
+		}
+	}
+	return fields
+}
+
+// AllErrorCodes returns the top-level error code followed by the codes of any
+// per-item errors, deduplicated in first-seen order, for labeling metrics
+// Returns an empty slice if no errors are present, as for success responses
+func (h *Handler) AllErrorCodes() []string {
+	codes := []string{}
+	if h == nil {
+		return codes
+	}
+
+	seen := make(map[string]bool)
+	errs := append([]*ResponseError{h.GetError()}, h.PartialErrors()...)
+	for _, e := range errs {
+		if e == nil || e.Code == "" || seen[e.Code] {
+			continue
+		}
+		seen[e.Code] = true
+		codes = append(codes, e.Code)
+	}
+	return codes
+}
@@ -0,0 +1,150 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialSuccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		partial   bool
+		errorsLen int
+	}{
+		{
+			name:    "fully successful",
+			body:    `{"success": true, "data": [{"id": 1}, {"id": 2}]}`,
+			partial: false,
+		},
+		{
+			name: "partially successful",
+			body: `{
+				"success": true,
+				"data": [{"id": 1}],
+				"errors": [{"code": "DUPLICATE", "message": "item 2 already exists", "field": "items[1]"}]
+			}`,
+			partial:   true,
+			errorsLen: 1,
+		},
+		{
+			name: "fully failed",
+			body: `{
+				"success": false,
+				"error": {"code": "BATCH_FAILED", "message": "all items failed"},
+				"errors": [{"code": "INVALID", "message": "bad item"}, {"code": "INVALID", "message": "bad item"}]
+			}`,
+			partial:   false,
+			errorsLen: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.partial, handler.IsPartialSuccess())
+			assert.Len(t, handler.PartialErrors(), tt.errorsLen)
+		})
+	}
+}
+
+func TestPartialErrorsFields(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"errors": [{"code": "DUPLICATE", "message": "exists", "field": "items[1]"}]
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	errs := handler.PartialErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "DUPLICATE", errs[0].Code)
+	assert.Equal(t, "items[1]", errs[0].Field)
+}
+
+func TestFieldErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected map[string]string
+	}{
+		{
+			name:     "single field error",
+			body:     `{"success": false, "error": {"code": "INVALID_EMAIL", "message": "Email format is invalid", "field": "email"}}`,
+			expected: map[string]string{"email": "Email format is invalid"},
+		},
+		{
+			name: "multiple field errors",
+			body: `{
+				"success": false,
+				"error": {"code": "VALIDATION", "message": "validation failed"},
+				"errors": [
+					{"code": "REQUIRED", "message": "name is required", "field": "name"},
+					{"code": "TOO_SHORT", "message": "password is too short", "field": "password"},
+					{"code": "GENERIC", "message": "no field"}
+				]
+			}`,
+			expected: map[string]string{"name": "name is required", "password": "password is too short"},
+		},
+		{
+			name:     "success",
+			body:     `{"success": true}`,
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, handler.FieldErrors())
+		})
+	}
+}
+
+func TestAllErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "success",
+			body: `{"success": true, "data": {"id": 1}}`,
+			want: []string{},
+		},
+		{
+			name: "single error",
+			body: `{"success": false, "error": {"code": "NOT_FOUND", "message": "missing"}}`,
+			want: []string{"NOT_FOUND"},
+		},
+		{
+			name: "multiple errors with duplicates",
+			body: `{
+				"success": false,
+				"error": {"code": "VALIDATION_FAILED", "message": "invalid input"},
+				"errors": [
+					{"code": "REQUIRED", "message": "name is required", "field": "name"},
+					{"code": "VALIDATION_FAILED", "message": "bad email", "field": "email"},
+					{"code": "REQUIRED", "message": "age is required", "field": "age"}
+				]
+			}`,
+			want: []string{"VALIDATION_FAILED", "REQUIRED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, handler.AllErrorCodes())
+		})
+	}
+
+	var handler *Handler
+	assert.Equal(t, []string{}, handler.AllErrorCodes())
+}
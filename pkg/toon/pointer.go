@@ -0,0 +1,100 @@
+package toon
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// DataPointer returns the raw JSON value at an RFC 6901 JSON Pointer within
+// the data, e.g. "/user/addresses/0/city"; "~1" and "~0" in a reference token
+// stand for "/" and "~", and the empty pointer selects the whole data
+// Returns ValidationError with code EMPTY_DATA if the pointer does not resolve
+// and INVALID_RESPONSE if it is malformed
+func (h *Handler) DataPointer(pointer string) (json.RawMessage, error) {
+	if h == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "json pointer must be empty or start with /",
+			Context: map[string]interface{}{
+				"pointer": pointer,
+			},
+		}
+	}
+
+	current := h.GetData()
+	if len(current) == 0 {
+		return nil, pointerNotFound(pointer, "")
+	}
+	if pointer == "" {
+		return current, nil
+	}
+
+	resolved := ""
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		resolved += "/" + escapePointerToken(token)
+
+		next, ok := pointerStep(current, token)
+		if !ok {
+			return nil, pointerNotFound(pointer, resolved)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// pointerStep resolves one reference token against an object or array
+func pointerStep(value json.RawMessage, token string) (json.RawMessage, bool) {
+	switch dataKindOf(value) {
+	case DataKindObject:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(value, &obj); err != nil {
+			return nil, false
+		}
+		next, ok := obj[token]
+		return next, ok
+
+	case DataKindArray:
+		// Indices are decimal with no leading zeros; "-" never resolves
+		if token == "" || (len(token) > 1 && token[0] == '0') {
+			return nil, false
+		}
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 {
+			return nil, false
+		}
+		var arr []json.RawMessage
+		if err := json.Unmarshal(value, &arr); err != nil || index >= len(arr) {
+			return nil, false
+		}
+		return arr[index], true
+
+	default:
+		return nil, false
+	}
+}
+
+// escapePointerToken re-escapes a reference token for error context
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// pointerNotFound builds the error for a pointer that does not resolve
+func pointerNotFound(pointer, failedAt string) error {
+	return &ValidationError{
+		Code:    ErrCodeEmptyData,
+		Message: "json pointer not found in response data",
+		Context: map[string]interface{}{
+			"pointer":   pointer,
+			"failed_at": failedAt,
+		},
+	}
+}
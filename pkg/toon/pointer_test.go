@@ -0,0 +1,97 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pointerBody = `{
+	"success": true,
+	"data": {
+		"user": {
+			"name": "Jane",
+			"addresses": [
+				{"city": "Dhaka"},
+				{"city": "Chittagong"}
+			]
+		},
+		"a/b": 1,
+		"m~n": 2,
+		"": 3
+	}
+}`
+
+func TestDataPointer(t *testing.T) {
+	handler, err := NewHandler([]byte(pointerBody))
+	require.NoError(t, err)
+
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{"/user/name", `"Jane"`},
+		{"/user/addresses/0/city", `"Dhaka"`},
+		{"/user/addresses/1", `{"city": "Chittagong"}`},
+		{"/a~1b", `1`},
+		{"/m~0n", `2`},
+		{"/", `3`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			got, err := handler.DataPointer(tt.pointer)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+
+	whole, err := handler.DataPointer("")
+	require.NoError(t, err)
+	assert.Equal(t, handler.GetData(), whole)
+}
+
+func TestDataPointerMissing(t *testing.T) {
+	handler, err := NewHandler([]byte(pointerBody))
+	require.NoError(t, err)
+
+	tests := []struct {
+		pointer  string
+		failedAt string
+	}{
+		{"/user/email", "/user/email"},
+		{"/user/addresses/2/city", "/user/addresses/2"},
+		{"/user/addresses/01", "/user/addresses/01"},
+		{"/user/addresses/-", "/user/addresses/-"},
+		{"/user/name/first", "/user/name/first"},
+		{"/a~1b/c", "/a~1b/c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			_, err := handler.DataPointer(tt.pointer)
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+			assert.Equal(t, tt.failedAt, valErr.Context["failed_at"])
+		})
+	}
+}
+
+func TestDataPointerInvalid(t *testing.T) {
+	handler, err := NewHandler([]byte(pointerBody))
+	require.NoError(t, err)
+
+	var valErr *ValidationError
+	_, err = handler.DataPointer("user/name")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+
+	empty, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	_, err = empty.DataPointer("/user")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+}
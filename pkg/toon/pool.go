@@ -0,0 +1,68 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// dataBufferPool holds buffers reused by UnmarshalDataPooled
+var dataBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// maxPooledBufferSize caps the buffers returned to the pool so that one huge
+// payload does not pin memory for the life of the process
+const maxPooledBufferSize = 1 << 20
+
+// UnmarshalDataPooled behaves like UnmarshalData but copies the data into a
+// pooled buffer instead of allocating a fresh copy on every call
+// The data is copied under the read lock and decoded after releasing it, so
+// an UnmarshalJSON method may call back into the handler and can never
+// modify the handler's data
+// As with encoding/json, an UnmarshalJSON method must copy its input to
+// retain it, since the buffer is reused once the call returns
+// The decode cache is not consulted; with transforms registered the data is
+// copied as in UnmarshalData, since transforms may modify their input
+func (h *Handler) UnmarshalDataPooled(v interface{}) error {
+	if v == nil {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "target interface is nil",
+		}
+	}
+
+	if opts := h.options(); opts.unwrapStringData || len(opts.dataTransforms) > 0 {
+		data, err := h.decodableData()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return decodeError(err, len(data), v)
+		}
+		return nil
+	}
+
+	buf := dataBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			buf.Reset()
+			dataBufferPool.Put(buf)
+		}
+	}()
+
+	buf.Reset()
+	if !h.copyDataTo(buf) {
+		return &ValidationError{
+			Code:    ErrCodeEmptyData,
+			Message: "response data is empty",
+		}
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), v); err != nil {
+		return decodeError(err, buf.Len(), v)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package toon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalDataPooled(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 42, "name": "test"}}`))
+	require.NoError(t, err)
+
+	var data struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	require.NoError(t, handler.UnmarshalDataPooled(&data))
+	assert.Equal(t, 42, data.ID)
+	assert.Equal(t, "test", data.Name)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, handler.UnmarshalDataPooled(nil), &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+
+	var wrong []string
+	require.ErrorAs(t, handler.UnmarshalDataPooled(&wrong), &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+
+	empty, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	require.ErrorAs(t, empty.UnmarshalDataPooled(&data), &valErr)
+	assert.Equal(t, ErrCodeEmptyData, valErr.Code)
+}
+
+func TestUnmarshalDataPooledConcurrent(t *testing.T) {
+	first, err := NewHandler([]byte(`{"success": true, "data": {"name": "first"}}`))
+	require.NoError(t, err)
+	second, err := NewHandler([]byte(`{"success": true, "data": {"name": "second-longer-value"}}`))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			handler, expected := first, "first"
+			if i%2 == 0 {
+				handler, expected = second, "second-longer-value"
+			}
+
+			var data struct {
+				Name string `json:"name"`
+			}
+			if assert.NoError(t, handler.UnmarshalDataPooled(&data)) {
+				assert.Equal(t, expected, data.Name)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// scribbler overwrites the input it is given, which UnmarshalJSON must not do
+type scribbler struct{}
+
+func (scribbler) UnmarshalJSON(data []byte) error {
+	for i := range data {
+		data[i] = 'X'
+	}
+	return nil
+}
+
+// reentrant calls back into its handler while being decoded
+type reentrant struct {
+	handler *Handler
+}
+
+func (r *reentrant) UnmarshalJSON([]byte) error {
+	return r.handler.SetRequestID("req-from-decoder")
+}
+
+func TestUnmarshalDataPooledDoesNotShareData(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	var target scribbler
+	require.NoError(t, handler.UnmarshalDataPooled(&target))
+	assert.JSONEq(t, `{"id": 1}`, string(handler.GetData()))
+}
+
+func TestUnmarshalDataPooledReentrant(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.UnmarshalDataPooled(&reentrant{handler: handler})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("UnmarshalDataPooled deadlocked")
+	}
+	assert.Equal(t, "req-from-decoder", handler.GetRequestID())
+}
+
+func BenchmarkUnmarshalDataPooled(b *testing.B) {
+	type TestData struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	handler, _ := NewHandler([]byte(`{
+		"success": true,
+		"data": {"id": 42, "name": "test"}
+	}`))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var data TestData
+		_ = handler.UnmarshalDataPooled(&data)
+	}
+}
+
+// BenchmarkUnmarshalDataVsPooled compares UnmarshalDataPooled with
+// UnmarshalData on a small and a larger payload
+func BenchmarkUnmarshalDataVsPooled(b *testing.B) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	items := make([]string, 200)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"id": %d, "name": "item-%d"}`, i, i)
+	}
+	payloads := map[string]string{
+		"small": `{"id": 42, "name": "test"}`,
+		"large": "[" + strings.Join(items, ",") + "]",
+	}
+
+	for _, size := range []string{"small", "large"} {
+		handler, err := NewHandler([]byte(`{"success": true, "data": ` + payloads[size] + `}`))
+		require.NoError(b, err)
+
+		decode := func(fn func(interface{}) error) func(*testing.B) {
+			return func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if size == "small" {
+						var data item
+						_ = fn(&data)
+					} else {
+						var data []item
+						_ = fn(&data)
+					}
+				}
+			}
+		}
+		b.Run(size+"/UnmarshalData", decode(handler.UnmarshalData))
+		b.Run(size+"/UnmarshalDataPooled", decode(handler.UnmarshalDataPooled))
+	}
+}
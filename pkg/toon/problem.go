@@ -0,0 +1,72 @@
+package toon
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem documents
+const ProblemContentType = "application/problem+json"
+
+// problemDocument is an RFC 7807 problem document with the Toon error code
+// and field carried as extension members
+type problemDocument struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	Field    string `json:"field,omitempty"`
+}
+
+// ToProblemJSON converts the error response into an RFC 7807 problem document
+// The status comes from HTTPStatus and the title is its status text; detail is
+// the error message and instance is the request ID when one is known
+// Returns a ValidationError if the response does not carry an error
+func (h *Handler) ToProblemJSON() ([]byte, error) {
+	if h == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	respErr := h.GetError()
+	if respErr == nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "response has no error to convert",
+		}
+	}
+
+	status := h.HTTPStatus()
+	doc := problemDocument{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   respErr.Message,
+		Instance: h.GetRequestID(),
+		Code:     respErr.Code,
+		Field:    respErr.Field,
+	}
+	if doc.Instance == "" {
+		doc.Instance = respErr.RequestID
+	}
+	if respErr.Details != "" {
+		if doc.Detail != "" {
+			doc.Detail += ": "
+		}
+		doc.Detail += respErr.Details
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeJSONMarshal,
+			Message: "failed to marshal problem document",
+			Err:     err,
+		}
+	}
+	return data, nil
+}
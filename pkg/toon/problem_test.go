@@ -0,0 +1,69 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToProblemJSON(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {
+			"code": "NOT_FOUND",
+			"message": "User not found",
+			"details": "no user with id 42",
+			"field": "id"
+		},
+		"meta": {"request_id": "req-123"}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	doc, err := handler.ToProblemJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "User not found: no user with id 42",
+		"instance": "req-123",
+		"code": "NOT_FOUND",
+		"field": "id"
+	}`, string(doc))
+}
+
+func TestToProblemJSONMinimal(t *testing.T) {
+	body := []byte(`{"success": false, "error": {"code": "SOMETHING_ODD", "message": "odd", "request_id": "origin-1"}}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	doc, err := handler.ToProblemJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "odd",
+		"instance": "origin-1",
+		"code": "SOMETHING_ODD"
+	}`, string(doc))
+}
+
+func TestToProblemJSONSuccess(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	_, err = handler.ToProblemJSON()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+
+	var nilHandler *Handler
+	_, err = nilHandler.ToProblemJSON()
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+}
@@ -0,0 +1,54 @@
+package toon
+
+import (
+	"bytes"
+	"net/http"
+	"unicode/utf8"
+)
+
+// WithHTMLErrorPages makes FromHTTPResponseWithOptions report bodies that
+// look like HTML, such as a load balancer's 502 page, as PROXY_ERROR instead
+// of a raw JSON_UNMARSHAL error
+// It has no effect on handlers parsed from bytes
+func WithHTMLErrorPages() Option {
+	return func(o *handlerOptions) {
+		o.detectHTML = true
+	}
+}
+
+// htmlSnippetSize caps how much of an HTML body is kept in the error context
+const htmlSnippetSize = 256
+
+// isHTMLBody reports whether body looks like an HTML document rather than JSON
+// JSON can never start with '<', so this has no false positives on valid bodies
+func isHTMLBody(body []byte) bool {
+	body = bytes.TrimLeft(stripBOM(body), " \t\r\n")
+	return len(body) > 0 && body[0] == '<'
+}
+
+// htmlPageError builds the PROXY_ERROR returned for an HTML body
+func htmlPageError(httpResp *http.Response, body []byte) error {
+	return &ValidationError{
+		Code:    ErrCodeProxyError,
+		Message: "response body is an HTML page, likely from a proxy or load balancer",
+		Context: map[string]interface{}{
+			"status_code":  httpResp.StatusCode,
+			"content_type": httpResp.Header.Get("Content-Type"),
+			"snippet":      bodySnippet(body, htmlSnippetSize),
+		},
+	}
+}
+
+// bodySnippet returns at most n bytes of body without splitting a UTF-8 rune
+func bodySnippet(body []byte, n int) string {
+	body = bytes.TrimSpace(stripBOM(body))
+	if len(body) <= n {
+		return string(body)
+	}
+
+	cut := n
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut])
+}
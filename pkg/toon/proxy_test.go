@@ -0,0 +1,79 @@
+package toon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const badGatewayPage = `<!DOCTYPE html>
+<html>
+<head><title>502 Bad Gateway</title></head>
+<body><center><h1>502 Bad Gateway</h1></center></body>
+</html>`
+
+// serveHTML returns a response from a server that replies with an HTML page
+func serveHTML(t *testing.T, status int, page string) *http.Response {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(page))
+	}))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestFromHTTPResponseDetectsHTMLPage(t *testing.T) {
+	resp := serveHTML(t, http.StatusBadGateway, badGatewayPage)
+
+	handler, err := FromHTTPResponseWithOptions(resp, WithHTMLErrorPages())
+	require.Error(t, err)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeProxyError, valErr.Code)
+	assert.Equal(t, http.StatusBadGateway, valErr.Context["status_code"])
+	assert.Equal(t, "text/html", valErr.Context["content_type"])
+	assert.Contains(t, valErr.Context["snippet"], "502 Bad Gateway")
+}
+
+func TestFromHTTPResponseHTMLSnippetTruncated(t *testing.T) {
+	page := "<html>" + strings.Repeat("é", htmlSnippetSize) + "</html>"
+	resp := serveHTML(t, http.StatusBadGateway, page)
+
+	_, err := FromHTTPResponseWithOptions(resp, WithHTMLErrorPages())
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	snippet := valErr.Context["snippet"].(string)
+	assert.LessOrEqual(t, len(snippet), htmlSnippetSize)
+	assert.True(t, strings.HasPrefix(snippet, "<html>"))
+	assert.True(t, strings.HasSuffix(snippet, "é"))
+}
+
+func TestFromHTTPResponseHTMLDetectionOffByDefault(t *testing.T) {
+	resp := serveHTML(t, http.StatusBadGateway, badGatewayPage)
+
+	_, err := FromHTTPResponse(resp)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestIsHTMLBody(t *testing.T) {
+	assert.True(t, isHTMLBody([]byte("  \n<html></html>")))
+	assert.True(t, isHTMLBody([]byte("\xef\xbb\xbf<!DOCTYPE html>")))
+	assert.False(t, isHTMLBody([]byte(`{"success": true}`)))
+	assert.False(t, isHTMLBody(nil))
+}
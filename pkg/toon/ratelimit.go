@@ -0,0 +1,210 @@
+package toon
+
+import (
+	"context"
+	"time"
+)
+
+// GetRateLimitFor safely returns a copy of the named rate limit bucket
+// Returns nil if the bucket is not present
+func (h *Handler) GetRateLimitFor(name string) *RateLimit {
+	meta := h.GetMeta()
+	if meta == nil {
+		return nil
+	}
+
+	rl, ok := meta.RateLimits[name]
+	if !ok {
+		return nil
+	}
+	return &rl
+}
+
+// MostConstrainedLimit returns the rate limit with the fewest remaining requests
+// across the single rate limit and all named buckets; ties go to the limit
+// that resets last
+// Returns nil if no rate limit information is available
+func (h *Handler) MostConstrainedLimit() *RateLimit {
+	meta := h.GetMeta()
+	if meta == nil {
+		return nil
+	}
+
+	var most *RateLimit
+	consider := func(rl RateLimit) {
+		if most == nil || moreRestrictive(rl, *most) {
+			c := rl
+			most = &c
+		}
+	}
+
+	if meta.RateLimit != nil {
+		consider(*meta.RateLimit)
+	}
+	for _, rl := range meta.RateLimits {
+		consider(rl)
+	}
+	return most
+}
+
+// moreRestrictive reports whether a has fewer remaining requests than b,
+// or as many but a later reset
+func moreRestrictive(a, b RateLimit) bool {
+	return a.Remaining < b.Remaining ||
+		(a.Remaining == b.Remaining && a.Reset.After(b.Reset))
+}
+
+// WaitForReset blocks until the rate limit resets when the response is rate limited
+// It returns immediately if the response is not rate limited or the reset time has passed
+// Returns the context error if ctx is done before the reset
+func (h *Handler) WaitForReset(ctx context.Context) error {
+	if !h.IsRateLimited() {
+		return nil
+	}
+
+	rl := h.MostConstrainedLimit()
+	if rl == nil || rl.Reset.IsZero() {
+		return nil
+	}
+
+	wait := rl.Reset.Sub(Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RetryAfter returns how long to wait before retrying the request
+// The Retry-After header is preferred when present; otherwise the time until
+// the rate limit reset is used when the response is rate limited
+// Returns zero if the request can be retried immediately
+func (h *Handler) RetryAfter() time.Duration {
+	if wait, ok := h.RetryAfterHeader(); ok {
+		return wait
+	}
+
+	if !h.IsRateLimited() {
+		return 0
+	}
+
+	rl := h.MostConstrainedLimit()
+	if rl == nil || rl.Reset.IsZero() {
+		return 0
+	}
+
+	wait := rl.Reset.Sub(Now())
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// CanRetryNow reports whether the request can be retried immediately: either
+// no rate limit is exhausted, or every exhausted limit has already reset
+// An exhausted limit without a reset time is treated as still in effect
+func (h *Handler) CanRetryNow() bool {
+	if h == nil {
+		return true
+	}
+
+	meta := h.GetMeta()
+	if meta == nil {
+		return true
+	}
+
+	now := Now()
+	elapsed := func(rl RateLimit) bool {
+		return rl.Remaining > 0 || (!rl.Reset.IsZero() && !rl.Reset.After(now))
+	}
+
+	if meta.RateLimit != nil && !elapsed(*meta.RateLimit) {
+		return false
+	}
+	for _, rl := range meta.RateLimits {
+		if !elapsed(rl) {
+			return false
+		}
+	}
+	return true
+}
+
+// RateLimitState classifies how close a response is to its rate limit
+type RateLimitState int
+
+const (
+	// RateLimitUnknown means the response carried no rate limit information
+	RateLimitUnknown RateLimitState = iota
+	// RateLimitOK means plenty of quota remains
+	RateLimitOK
+	// RateLimitWarning means the remaining quota is below the warning threshold
+	RateLimitWarning
+	// RateLimitExhausted means no quota remains
+	RateLimitExhausted
+)
+
+// DefaultRateLimitWarningThreshold is the fraction of the limit below which
+// RateLimitState reports RateLimitWarning
+var DefaultRateLimitWarningThreshold = 0.10
+
+// String returns the lowercase name of the state
+func (s RateLimitState) String() string {
+	switch s {
+	case RateLimitOK:
+		return "ok"
+	case RateLimitWarning:
+		return "warning"
+	case RateLimitExhausted:
+		return "exhausted"
+	default:
+		return "unknown"
+	}
+}
+
+// RateLimitState returns the rate limit state using DefaultRateLimitWarningThreshold
+func (h *Handler) RateLimitState() RateLimitState {
+	return h.RateLimitStateWithThreshold(DefaultRateLimitWarningThreshold)
+}
+
+// RateLimitStateWithThreshold returns the most severe state across the single
+// rate limit and all named buckets; a bucket is in RateLimitWarning when its
+// remaining quota is below threshold (a fraction of its limit, e.g. 0.1)
+func (h *Handler) RateLimitStateWithThreshold(threshold float64) RateLimitState {
+	if h == nil {
+		return RateLimitUnknown
+	}
+
+	meta := h.GetMeta()
+	if meta == nil {
+		return RateLimitUnknown
+	}
+
+	state := RateLimitUnknown
+	if meta.RateLimit != nil {
+		state = max(state, rateLimitStateOf(*meta.RateLimit, threshold))
+	}
+	for _, rl := range meta.RateLimits {
+		state = max(state, rateLimitStateOf(rl, threshold))
+	}
+	return state
+}
+
+// rateLimitStateOf classifies a single rate limit
+// A missing or non-positive limit cannot produce a warning
+func rateLimitStateOf(rl RateLimit, threshold float64) RateLimitState {
+	if rl.Remaining <= 0 {
+		return RateLimitExhausted
+	}
+	if rl.Limit > 0 && float64(rl.Remaining) < threshold*float64(rl.Limit) {
+		return RateLimitWarning
+	}
+	return RateLimitOK
+}
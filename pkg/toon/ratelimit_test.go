@@ -0,0 +1,154 @@
+package toon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForReset(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 5}}}`))
+	require.NoError(t, err)
+	assert.NoError(t, handler.WaitForReset(context.Background()))
+
+	reset := time.Now().Add(time.Hour).Format(time.RFC3339)
+	handler, err = NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "` + reset + `"}}}`))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, handler.WaitForReset(ctx), context.DeadlineExceeded)
+}
+
+func TestNamedRateLimits(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"meta": {
+			"rate_limit": {"limit": 1000, "remaining": 900, "reset": "2025-01-01T01:00:00Z"},
+			"rate_limits": {
+				"search": {"limit": 30, "remaining": 5, "reset": "2025-01-01T00:01:00Z"},
+				"upload": {"limit": 10, "remaining": 8, "reset": 1735693200}
+			}
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	search := handler.GetRateLimitFor("search")
+	require.NotNil(t, search)
+	assert.Equal(t, 30, search.Limit)
+	assert.Equal(t, 5, search.Remaining)
+
+	upload := handler.GetRateLimitFor("upload")
+	require.NotNil(t, upload)
+	assert.Equal(t, time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC), upload.Reset)
+
+	assert.Nil(t, handler.GetRateLimitFor("missing"))
+
+	most := handler.MostConstrainedLimit()
+	require.NotNil(t, most)
+	assert.Equal(t, 5, most.Remaining)
+	assert.False(t, handler.IsRateLimited())
+	assert.Equal(t, 900, handler.GetRateLimit().Remaining)
+}
+
+func TestNamedRateLimitExhausted(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"meta": {
+			"rate_limit": {"limit": 1000, "remaining": 900},
+			"rate_limits": {"search": {"limit": 30, "remaining": 0}}
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.True(t, handler.IsRateLimited())
+	assert.Equal(t, 30, handler.MostConstrainedLimit().Limit)
+}
+
+func TestMostConstrainedLimitWithoutInfo(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	assert.Nil(t, handler.MostConstrainedLimit())
+	assert.False(t, handler.IsRateLimited())
+}
+
+func TestRateLimitState(t *testing.T) {
+	tests := []struct {
+		name string
+		meta string
+		want RateLimitState
+	}{
+		{"unknown without meta", ``, RateLimitUnknown},
+		{"unknown without rate limit", `, "meta": {"request_id": "r"}`, RateLimitUnknown},
+		{"ok", `, "meta": {"rate_limit": {"limit": 100, "remaining": 50}}`, RateLimitOK},
+		{"warning", `, "meta": {"rate_limit": {"limit": 100, "remaining": 9}}`, RateLimitWarning},
+		{"exhausted", `, "meta": {"rate_limit": {"limit": 100, "remaining": 0}}`, RateLimitExhausted},
+		{"worst bucket wins", `, "meta": {"rate_limit": {"limit": 100, "remaining": 90}, "rate_limits": {"writes": {"limit": 10, "remaining": 0}}}`, RateLimitExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(`{"success": true` + tt.meta + `}`))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, handler.RateLimitState())
+		})
+	}
+}
+
+func TestRateLimitStateThreshold(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 100, "remaining": 20}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, RateLimitOK, handler.RateLimitStateWithThreshold(0.1))
+	assert.Equal(t, RateLimitWarning, handler.RateLimitStateWithThreshold(0.25))
+
+	original := DefaultRateLimitWarningThreshold
+	DefaultRateLimitWarningThreshold = 0.5
+	t.Cleanup(func() { DefaultRateLimitWarningThreshold = original })
+	assert.Equal(t, RateLimitWarning, handler.RateLimitState())
+}
+
+func TestRateLimitStateString(t *testing.T) {
+	assert.Equal(t, "unknown", RateLimitUnknown.String())
+	assert.Equal(t, "ok", RateLimitOK.String())
+	assert.Equal(t, "warning", RateLimitWarning.String())
+	assert.Equal(t, "exhausted", RateLimitExhausted.String())
+
+	var handler *Handler
+	assert.Equal(t, RateLimitUnknown, handler.RateLimitState())
+}
+
+func TestCanRetryNow(t *testing.T) {
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name string
+		meta string
+		want bool
+	}{
+		{"no meta", ``, true},
+		{"not rate limited", `, "meta": {"rate_limit": {"limit": 10, "remaining": 5, "reset": "` + future + `"}}`, true},
+		{"exhausted with past reset", `, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "` + past + `"}}`, true},
+		{"exhausted with future reset", `, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "` + future + `"}}`, false},
+		{"exhausted without reset", `, "meta": {"rate_limit": {"limit": 10, "remaining": 0}}`, false},
+		{"exhausted bucket with future reset", `, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "` + past + `"}, "rate_limits": {"writes": {"limit": 5, "remaining": 0, "reset": "` + future + `"}}}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(`{"success": true` + tt.meta + `}`))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, handler.CanRetryNow())
+		})
+	}
+
+	var handler *Handler
+	assert.True(t, handler.CanRetryNow())
+}
@@ -0,0 +1,13 @@
+package toon
+
+import (
+	"bytes"
+	"io"
+)
+
+// DataReader returns an io.Reader over a copy of the raw data bytes for use
+// with io.Copy and other streaming consumers
+// Returns an empty reader if no data is present
+func (h *Handler) DataReader() io.Reader {
+	return bytes.NewReader(h.AppendDataTo(nil))
+}
@@ -0,0 +1,35 @@
+package toon
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataReader(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "data": {"id": 1, "items": [1, 2, 3]}}`))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, handler.DataReader())
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(handler.GetData())), n)
+	assert.Equal(t, []byte(handler.GetData()), buf.Bytes())
+}
+
+func TestDataReaderEmpty(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(handler.DataReader())
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	var nilHandler *Handler
+	data, err = io.ReadAll(nilHandler.DataReader())
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
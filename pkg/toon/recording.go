@@ -0,0 +1,269 @@
+package toon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Recording is one response captured by RecordingTransport, written as a
+// single NDJSON line
+// Body holds the body as text when it is valid UTF-8 and RawBody holds it
+// otherwise, so recordings stay readable while every body replays byte for byte
+type Recording struct {
+	RecordedAt time.Time   `json:"recorded_at"`
+	Method     string      `json:"method,omitempty"`
+	URL        string      `json:"url,omitempty"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	RawBody    []byte      `json:"raw_body,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// RecordingTransport is an http.RoundTripper that writes every response to
+// W as NDJSON while passing the body through to the caller unchanged
+// Request URLs are redacted and credential headers such as Set-Cookie are
+// dropped from the recorded response headers; recording is best effort, so a failed write
+// to W never fails the request
+type RecordingTransport struct {
+	// Base performs the requests; http.DefaultTransport is used when nil
+	Base http.RoundTripper
+
+	// W receives one Recording per line
+	W io.Writer
+
+	mu sync.Mutex
+}
+
+// NewRecordingTransport creates a RecordingTransport writing to w
+func NewRecordingTransport(base http.RoundTripper, w io.Writer) *RecordingTransport {
+	return &RecordingTransport{Base: base, W: w}
+}
+
+// RoundTrip implements http.RoundTripper
+// The body is passed through as the caller reads it; the recording is written
+// once the caller reaches EOF or closes the body. A read error, or a body
+// closed before EOF, is recorded along with the bytes read until then
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		return resp, err
+	}
+
+	rec := Recording{
+		RecordedAt: Now(),
+		Method:     req.Method,
+		StatusCode: resp.StatusCode,
+		Header:     recordedHeader(resp.Header),
+	}
+	if req.URL != nil {
+		rec.URL = req.URL.Redacted()
+	}
+
+	body := &recordingBody{body: resp.Body}
+	body.tee = io.TeeReader(resp.Body, body)
+	body.record = func(data []byte, readErr error) {
+		if utf8.Valid(data) {
+			rec.Body = string(data)
+		} else {
+			rec.RawBody = data
+		}
+		if readErr != nil {
+			rec.Error = readErr.Error()
+		}
+		t.write(&rec)
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// errBodyClosedEarly is recorded when the caller closes the body before EOF
+var errBodyClosedEarly = errors.New("response body closed before EOF")
+
+// recordingBody copies the body into buf as the caller reads it and calls
+// record once, on EOF, a read error or Close
+type recordingBody struct {
+	body   io.ReadCloser
+	tee    io.Reader
+	record func(data []byte, err error)
+
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	done bool
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.tee.Read(p)
+	if err != nil {
+		b.finish(err)
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.body.Close()
+	b.finish(errBodyClosedEarly)
+	return err
+}
+
+// Write implements io.Writer for the TeeReader
+func (b *recordingBody) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// finish records the body read so far unless it has already been recorded
+// io.EOF is recorded as a complete body
+func (b *recordingBody) finish(err error) {
+	b.mu.Lock()
+	if b.done {
+		b.mu.Unlock()
+		return
+	}
+	b.done = true
+	data := bytes.Clone(b.buf.Bytes())
+	b.mu.Unlock()
+
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	b.record(data, err)
+}
+
+// write appends rec to W as a single line
+func (t *RecordingTransport) write(rec *Recording) {
+	if t.W == nil {
+		return
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.W.Write(line)
+}
+
+// unrecordedHeaders lists the credential headers never written to a recording
+var unrecordedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// recordedHeader returns a copy of header without unrecordedHeaders
+func recordedHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	recorded := make(http.Header, len(header))
+	for name, values := range header {
+		if isUnrecordedHeader(name) {
+			continue
+		}
+		recorded[name] = append([]string(nil), values...)
+	}
+	return recorded
+}
+
+// isUnrecordedHeader reports whether name is listed in unrecordedHeaders
+// Header names are matched case-insensitively
+func isUnrecordedHeader(name string) bool {
+	for _, unrecorded := range unrecordedHeaders {
+		if strings.EqualFold(name, unrecorded) {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamHandler reads a sequence of Toon responses from NDJSON input
+// StreamHandler is not safe for concurrent use
+type StreamHandler struct {
+	r     *bufio.Reader
+	parse func([]byte) (*Handler, error)
+}
+
+// NewStreamHandler creates a StreamHandler reading one Toon response per line from r
+func NewStreamHandler(r io.Reader) *StreamHandler {
+	return &StreamHandler{r: bufio.NewReader(r), parse: NewHandler}
+}
+
+// ReplayFromReader creates a StreamHandler replaying the Recordings written
+// by RecordingTransport
+// Each replayed handler is built as FromHTTPResponse would build it, so
+// status code and header-derived state match the original response
+func ReplayFromReader(r io.Reader) *StreamHandler {
+	return &StreamHandler{r: bufio.NewReader(r), parse: replayRecording}
+}
+
+// Next returns the handler for the next non-blank line
+// Returns io.EOF at the end of the stream
+func (s *StreamHandler) Next() (*Handler, error) {
+	for {
+		line, err := s.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, &ValidationError{
+				Code:    ErrCodeIORead,
+				Message: "failed to read response stream",
+				Err:     err,
+			}
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			return s.parse(line)
+		}
+		if err != nil {
+			return nil, io.EOF
+		}
+	}
+}
+
+// replayRecording rebuilds the handler for a single Recording line
+func replayRecording(line []byte) (*Handler, error) {
+	var rec Recording
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return nil, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to parse recording",
+			Err:     err,
+		}
+	}
+	if rec.Error != "" {
+		return nil, &ValidationError{
+			Code:    ErrCodeIORead,
+			Message: "recorded response body failed to read",
+			Err:     errors.New(rec.Error),
+			Context: map[string]interface{}{
+				"status_code": rec.StatusCode,
+				"url":         rec.URL,
+			},
+		}
+	}
+
+	body := rec.RawBody
+	if body == nil {
+		body = []byte(rec.Body)
+	}
+
+	return FromHTTPResponse(&http.Response{
+		StatusCode:    rec.StatusCode,
+		Header:        rec.Header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	})
+}
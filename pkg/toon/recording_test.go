@@ -0,0 +1,189 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingTransportRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(MockServer(map[string][]byte{
+		"/users": []byte(`{"success": true, "data": {"id": 1}}`),
+		"/fail":  []byte(`{"success": false, "error": {"code": "NOT_FOUND", "message": "missing"}}`),
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := &http.Client{Transport: NewRecordingTransport(nil, &log)}
+
+	resp, err := client.Get(server.URL + "/users")
+	require.NoError(t, err)
+	first, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	resp, err = client.Get(server.URL + "/fail")
+	require.NoError(t, err)
+	second, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, strings.Count(log.String(), "\n"))
+
+	stream := ReplayFromReader(&log)
+
+	replayed, err := stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, first.RawBody(), replayed.RawBody())
+	assert.Equal(t, first.StatusCode(), replayed.StatusCode())
+	assert.True(t, replayed.IsSuccess())
+
+	replayed, err = stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, second.RawBody(), replayed.RawBody())
+	assert.Equal(t, second.StatusCode(), replayed.StatusCode())
+	assert.Equal(t, "NOT_FOUND", replayed.GetError().Code)
+
+	_, err = stream.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestRecordingTransportPassesBodyThrough(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusBadGateway, http.Header{
+		"Content-Type": {"text/plain"},
+		"Set-Cookie":   {"session=secret"},
+	}, "upstream down")
+
+	var log bytes.Buffer
+	client := &http.Client{Transport: NewRecordingTransport(nil, &log)}
+
+	resp, err := client.Get(server.URL + "/path")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "upstream down", string(body))
+
+	var rec Recording
+	require.NoError(t, json.Unmarshal(log.Bytes(), &rec))
+	assert.Equal(t, http.MethodGet, rec.Method)
+	assert.Equal(t, server.URL+"/path", rec.URL)
+	assert.Equal(t, http.StatusBadGateway, rec.StatusCode)
+	assert.Equal(t, "upstream down", rec.Body)
+	assert.Nil(t, rec.RawBody)
+	assert.Equal(t, "text/plain", rec.Header.Get("Content-Type"))
+	assert.Empty(t, rec.Header.Get("Set-Cookie"))
+}
+
+func TestRecordingTransportStreamsBody(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, nil, `{"success": true, "data": "streamed"}`)
+
+	var log bytes.Buffer
+	client := &http.Client{Transport: NewRecordingTransport(nil, &log)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	assert.Zero(t, log.Len(), "nothing is recorded before the body is read")
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(resp.Body, buf)
+	require.NoError(t, err)
+	assert.Zero(t, log.Len())
+
+	rest, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"success": true, "data": "streamed"}`, string(buf)+string(rest))
+	require.NoError(t, resp.Body.Close())
+
+	var rec Recording
+	require.NoError(t, json.Unmarshal(log.Bytes(), &rec))
+	assert.Equal(t, `{"success": true, "data": "streamed"}`, rec.Body)
+	assert.Empty(t, rec.Error)
+	assert.Equal(t, 1, strings.Count(log.String(), "\n"))
+}
+
+func TestRecordingTransportClosedEarly(t *testing.T) {
+	server := serveWithHeaders(t, http.StatusOK, nil, `{"success": true}`)
+
+	var log bytes.Buffer
+	client := &http.Client{Transport: NewRecordingTransport(nil, &log)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(resp.Body, buf)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	var rec Recording
+	require.NoError(t, json.Unmarshal(log.Bytes(), &rec))
+	assert.Equal(t, `{"su`, rec.Body)
+	assert.Equal(t, errBodyClosedEarly.Error(), rec.Error)
+
+	_, err = ReplayFromReader(&log).Next()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeIORead, valErr.Code)
+}
+
+func TestRecordingTransportUsesClock(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedNow(t, at)
+
+	server := serveWithHeaders(t, http.StatusOK, nil, `{"success": true}`)
+
+	var log bytes.Buffer
+	client := &http.Client{Transport: NewRecordingTransport(nil, &log)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	var rec Recording
+	require.NoError(t, json.Unmarshal(log.Bytes(), &rec))
+	assert.True(t, at.Equal(rec.RecordedAt))
+}
+
+func TestReplayFromReaderBinaryBody(t *testing.T) {
+	var log bytes.Buffer
+	transport := NewRecordingTransport(nil, &log)
+	transport.write(&Recording{StatusCode: http.StatusOK, RawBody: []byte{0xff, 0xfe}})
+
+	var rec Recording
+	require.NoError(t, json.Unmarshal(log.Bytes(), &rec))
+	assert.Equal(t, []byte{0xff, 0xfe}, rec.RawBody)
+
+	_, err := ReplayFromReader(&log).Next()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestReplayFromReaderRecordedReadError(t *testing.T) {
+	stream := ReplayFromReader(strings.NewReader(`{"status_code": 200, "error": "connection reset"}` + "\n"))
+
+	_, err := stream.Next()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeIORead, valErr.Code)
+}
+
+func TestStreamHandlerSkipsBlankLines(t *testing.T) {
+	stream := NewStreamHandler(strings.NewReader("{\"success\": true}\n\n{\"success\": false, \"error\": {\"code\": \"X\", \"message\": \"m\"}}"))
+
+	first, err := stream.Next()
+	require.NoError(t, err)
+	assert.True(t, first.IsSuccess())
+
+	second, err := stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "X", second.GetError().Code)
+
+	_, err = stream.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,19 @@
+package toon
+
+import "net/http"
+
+// requestContext describes the originating request for ValidationError context
+// Only the method and the URL, with any password redacted, are recorded
+func requestContext(req *http.Request) map[string]interface{} {
+	if req == nil {
+		return nil
+	}
+
+	ctx := map[string]interface{}{
+		"method": req.Method,
+	}
+	if req.URL != nil {
+		ctx["url"] = req.URL.Redacted()
+	}
+	return ctx
+}
@@ -1,16 +1,24 @@
 package toon
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
 	"time"
 )
 
 // Response represents a standard Toon API response wrapper
 type Response struct {
-	Success bool            `json:"success"`
-	Data    json.RawMessage `json:"data,omitempty"`
-	Error   *ResponseError  `json:"error,omitempty"`
-	Meta    *Meta           `json:"meta,omitempty"`
+	Success bool             `json:"success"`
+	Data    json.RawMessage  `json:"data,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+	Errors  []*ResponseError `json:"errors,omitempty"`
+	Meta    *Meta            `json:"meta,omitempty"`
+
+	// Results holds the raw sub-responses of a multi-status (207) batch
+	Results []json.RawMessage `json:"results,omitempty"`
 }
 
 // ResponseError represents error information in a Toon response
@@ -19,14 +27,247 @@ type ResponseError struct {
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
 	Field   string `json:"field,omitempty"`
+
+	// RequestID and Trace are optional server-side debugging details,
+	// typically only sent by non-production environments
+	RequestID string `json:"request_id,omitempty"`
+	Trace     string `json:"trace,omitempty"`
+
+	// Cause is the underlying error this one was caused by, if the server sent one
+	Cause *ResponseError `json:"cause,omitempty"`
+
+	// Extra holds any vendor-specific fields of the error object beyond the
+	// known ones above, keyed by field name
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// responseErrorFields are the error object keys decoded into named fields
+var responseErrorFields = []string{"code", "message", "details", "field", "request_id", "trace", "cause"}
+
+// UnmarshalJSON decodes error information
+// The code field accepts a string or a number; numbers are stored in their
+// decimal string form, so "code": 42 yields Code "42"
+func (e *ResponseError) UnmarshalJSON(data []byte) error {
+	type responseErrorAlias ResponseError
+	aux := struct {
+		*responseErrorAlias
+		Code json.RawMessage `json:"code"`
+	}{
+		responseErrorAlias: (*responseErrorAlias)(e),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	code, err := parseErrorCode(aux.Code)
+	if err != nil {
+		return err
+	}
+	e.Code = code
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, known := range responseErrorFields {
+		delete(fields, known)
+	}
+	e.Extra = nil
+	if len(fields) > 0 {
+		e.Extra = fields
+	}
+	return nil
+}
+
+// MarshalJSON encodes error information, including any Extra fields
+// Extra fields never override the known fields
+func (e ResponseError) MarshalJSON() ([]byte, error) {
+	type responseErrorAlias ResponseError
+	known, err := json.Marshal(responseErrorAlias(e))
+	if err != nil || len(e.Extra) == 0 {
+		return known, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range e.Extra {
+		if _, exists := fields[key]; !exists && !isResponseErrorField(key) {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// isResponseErrorField reports whether key is decoded into a named field
+func isResponseErrorField(key string) bool {
+	for _, known := range responseErrorFields {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
+// parseErrorCode parses an error code given as a JSON string or number
+func parseErrorCode(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", nil
+	}
+
+	if raw[0] == '"' {
+		var code string
+		if err := json.Unmarshal(raw, &code); err != nil {
+			return "", err
+		}
+		return code, nil
+	}
+
+	var code json.Number
+	if err := json.Unmarshal(raw, &code); err != nil {
+		return "", fmt.Errorf("invalid error code %s: %w", raw, err)
+	}
+	return code.String(), nil
+}
+
+// OriginRequestID returns the server-side request ID the error originated from
+// Returns empty string if the server did not provide one
+func (e *ResponseError) OriginRequestID() string {
+	if e == nil {
+		return ""
+	}
+	return e.RequestID
+}
+
+// Error implements the error interface using the ErrorString format
+func (e *ResponseError) Error() string {
+	return formatResponseError(e, false)
+}
+
+// Unwrap returns the cause, so errors.Unwrap, errors.Is and errors.As walk
+// the cause chain
+func (e *ResponseError) Unwrap() error {
+	if e == nil || e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// Equal reports whether two errors have the same code, message, details and field
+// Debugging fields, Cause and Extra are ignored; two nil errors are equal
+func (e *ResponseError) Equal(other *ResponseError) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return e.Code == other.Code &&
+		e.Message == other.Message &&
+		e.Details == other.Details &&
+		e.Field == other.Field
+}
+
+// TestingT is the subset of *testing.T used by AssertErrorEqual
+// It matches testify's assert.TestingT
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// AssertErrorEqual reports a test failure on t unless expected and actual
+// are Equal, returning whether they were
+func AssertErrorEqual(t TestingT, expected, actual *ResponseError) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if expected.Equal(actual) {
+		return true
+	}
+	t.Errorf("response errors differ:\nexpected: %s\nactual:   %s",
+		formatResponseError(expected, false), formatResponseError(actual, false))
+	return false
 }
 
 // Meta contains metadata about the response
 type Meta struct {
-	Timestamp   time.Time  `json:"timestamp,omitempty"`
-	RequestID   string     `json:"request_id,omitempty"`
-	APIVersion  string     `json:"api_version,omitempty"`
-	RateLimit   *RateLimit `json:"rate_limit,omitempty"`
+	Timestamp  time.Time            `json:"timestamp,omitempty"`
+	RequestID  string               `json:"request_id,omitempty"`
+	APIVersion string               `json:"api_version,omitempty"`
+	RateLimit  *RateLimit           `json:"rate_limit,omitempty"`
+	RateLimits map[string]RateLimit `json:"rate_limits,omitempty"`
+	Warnings   []string             `json:"warnings,omitempty"`
+	Sunset     *time.Time           `json:"sunset,omitempty"`
+	Pagination *Pagination          `json:"pagination,omitempty"`
+
+	// Extra holds any vendor-specific meta fields beyond the known ones
+	// above, keyed by field name
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// metaFields are the meta object keys decoded into named fields
+var metaFields = []string{
+	"timestamp", "request_id", "api_version", "rate_limit", "rate_limits",
+	"warnings", "sunset", "pagination",
+}
+
+// UnmarshalJSON decodes metadata, keeping unknown fields in Extra
+func (m *Meta) UnmarshalJSON(data []byte) error {
+	type metaAlias Meta
+	if err := json.Unmarshal(data, (*metaAlias)(m)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, known := range metaFields {
+		delete(fields, known)
+	}
+	m.Extra = nil
+	if len(fields) > 0 {
+		m.Extra = fields
+	}
+	return nil
+}
+
+// MarshalJSON encodes metadata, including any Extra fields
+// Extra fields never override the known fields
+func (m Meta) MarshalJSON() ([]byte, error) {
+	type metaAlias Meta
+	known, err := json.Marshal(metaAlias(m))
+	if err != nil || len(m.Extra) == 0 {
+		return known, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range m.Extra {
+		if _, exists := fields[key]; !exists && !isMetaField(key) {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// isMetaField reports whether key is decoded into a named field
+func isMetaField(key string) bool {
+	for _, known := range metaFields {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Pagination contains cursor-based pagination information
+type Pagination struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	HasMore    bool   `json:"has_more,omitempty"`
 }
 
 // RateLimit contains rate limiting information
@@ -34,4 +275,52 @@ type RateLimit struct {
 	Limit     int       `json:"limit"`
 	Remaining int       `json:"remaining"`
 	Reset     time.Time `json:"reset"`
-}
\ No newline at end of file
+}
+
+// UnmarshalJSON decodes rate limit information
+// The reset field accepts an RFC3339 string or a numeric epoch in seconds,
+// including fractional seconds
+func (rl *RateLimit) UnmarshalJSON(data []byte) error {
+	type rateLimitAlias RateLimit
+	aux := struct {
+		*rateLimitAlias
+		Reset json.RawMessage `json:"reset"`
+	}{
+		rateLimitAlias: (*rateLimitAlias)(rl),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	reset, err := parseReset(aux.Reset)
+	if err != nil {
+		return err
+	}
+	rl.Reset = reset
+	return nil
+}
+
+// parseReset parses a reset value given as an RFC3339 string or epoch seconds
+func parseReset(raw json.RawMessage) (time.Time, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return time.Time{}, nil
+	}
+
+	if raw[0] == '"' {
+		var t time.Time
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return time.Time{}, err
+		}
+		return t, nil
+	}
+
+	seconds, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid rate limit reset %s: %w", raw, err)
+	}
+
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))).UTC(), nil
+}
@@ -0,0 +1,276 @@
+package toon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitResetFormats(t *testing.T) {
+	expected := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		reset string
+	}{
+		{name: "rfc3339 string", reset: `"2025-12-31T23:59:59Z"`},
+		{name: "epoch seconds", reset: `1767225599`},
+		{name: "epoch float", reset: `1767225599.0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(`{
+				"success": true,
+				"meta": {"rate_limit": {"limit": 100, "remaining": 10, "reset": ` + tt.reset + `}}
+			}`)
+
+			handler, err := NewHandler(body)
+			require.NoError(t, err)
+
+			reset := handler.GetRateLimitReset()
+			require.NotNil(t, reset)
+			assert.True(t, expected.Equal(*reset))
+			assert.Equal(t, "10/100 requests remaining (reset: 2025-12-31T23:59:59Z)", handler.GetRateLimitStatus())
+		})
+	}
+}
+
+func TestRateLimitResetFractionalSeconds(t *testing.T) {
+	var rl RateLimit
+	require.NoError(t, rl.UnmarshalJSON([]byte(`{"limit": 1, "remaining": 1, "reset": 1767225599.5}`)))
+
+	assert.Equal(t, int64(1767225599), rl.Reset.Unix())
+	assert.Equal(t, 500*time.Millisecond, time.Duration(rl.Reset.Nanosecond()))
+}
+
+func TestRateLimitResetInvalid(t *testing.T) {
+	body := []byte(`{"success": true, "meta": {"rate_limit": {"limit": 1, "reset": true}}}`)
+
+	handler, err := NewHandler(body)
+	assert.Error(t, err)
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestDeprecationWarnings(t *testing.T) {
+	body := []byte(`{
+		"success": true,
+		"meta": {"warnings": ["v1 is deprecated, use v2"]}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1 is deprecated, use v2"}, handler.GetWarnings())
+	assert.Nil(t, handler.GetSunset())
+	assert.True(t, handler.IsDeprecated())
+}
+
+func TestDeprecationSunset(t *testing.T) {
+	future := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"sunset": "` + future.Format(time.RFC3339) + `"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, handler.GetSunset())
+	assert.True(t, future.Equal(*handler.GetSunset()))
+	assert.True(t, handler.IsDeprecated())
+
+	handler, err = NewHandler([]byte(`{"success": true, "meta": {"sunset": "` + past.Format(time.RFC3339) + `"}}`))
+	require.NoError(t, err)
+	assert.False(t, handler.IsDeprecated())
+
+	handler, err = NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	assert.Nil(t, handler.GetWarnings())
+	assert.False(t, handler.IsDeprecated())
+}
+
+func TestResponseErrorOrigin(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {
+			"code": "INTERNAL",
+			"message": "unexpected failure",
+			"request_id": "srv-456",
+			"trace": "main.go:42"
+		}
+	}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	respErr := handler.GetError()
+	require.NotNil(t, respErr)
+	assert.Equal(t, "srv-456", respErr.OriginRequestID())
+	assert.Equal(t, "main.go:42", respErr.Trace)
+	assert.Equal(t, "INTERNAL | unexpected failure", handler.ErrorString())
+	assert.Equal(t, "INTERNAL | unexpected failure | request_id: srv-456 | trace: main.go:42", handler.ErrorStringVerbose())
+}
+
+func TestResponseErrorWithoutOrigin(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "msg"}}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, handler.GetError().OriginRequestID())
+	assert.Equal(t, handler.ErrorString(), handler.ErrorStringVerbose())
+
+	var nilErr *ResponseError
+	assert.Empty(t, nilErr.OriginRequestID())
+}
+
+func TestResponseErrorCodeFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{name: "string code", code: `"NOT_FOUND"`, expected: "NOT_FOUND"},
+		{name: "integer code", code: `42`, expected: "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(`{"success": false, "error": {"code": ` + tt.code + `, "message": "msg", "field": "id"}}`)
+
+			handler, err := NewHandler(body)
+			require.NoError(t, err)
+			require.NotNil(t, handler.GetError())
+			assert.Equal(t, tt.expected, handler.GetError().Code)
+			assert.Equal(t, "msg", handler.GetError().Message)
+			assert.Equal(t, tt.expected+" | msg | field: id", handler.ErrorString())
+		})
+	}
+}
+
+func TestResponseErrorCodeInvalid(t *testing.T) {
+	_, err := NewHandler([]byte(`{"success": false, "error": {"code": {"nested": true}, "message": "msg"}}`))
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestResponseErrorEqual(t *testing.T) {
+	base := &ResponseError{Code: "INVALID", Message: "bad", Details: "why", Field: "email"}
+
+	same := &ResponseError{Code: "INVALID", Message: "bad", Details: "why", Field: "email", RequestID: "req-1"}
+	assert.True(t, base.Equal(same))
+	assert.True(t, same.Equal(base))
+
+	for name, other := range map[string]*ResponseError{
+		"code":    {Code: "OTHER", Message: "bad", Details: "why", Field: "email"},
+		"message": {Code: "INVALID", Message: "worse", Details: "why", Field: "email"},
+		"details": {Code: "INVALID", Message: "bad", Details: "because", Field: "email"},
+		"field":   {Code: "INVALID", Message: "bad", Details: "why", Field: "name"},
+	} {
+		assert.False(t, base.Equal(other), name)
+	}
+
+	var nilErr *ResponseError
+	assert.True(t, nilErr.Equal(nil))
+	assert.False(t, nilErr.Equal(base))
+	assert.False(t, base.Equal(nil))
+}
+
+// recordingT captures failures reported through TestingT
+type recordingT struct {
+	failures []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertErrorEqual(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "NOT_FOUND", "message": "missing"}}`))
+	require.NoError(t, err)
+
+	assert.True(t, AssertErrorEqual(t, &ResponseError{Code: "NOT_FOUND", Message: "missing"}, handler.GetError()))
+
+	rec := &recordingT{}
+	assert.False(t, AssertErrorEqual(rec, &ResponseError{Code: "NOT_FOUND", Message: "gone"}, handler.GetError()))
+	require.Len(t, rec.failures, 1)
+	assert.Contains(t, rec.failures[0], "NOT_FOUND | gone")
+	assert.Contains(t, rec.failures[0], "NOT_FOUND | missing")
+}
+
+func TestMetaExtraRoundTrip(t *testing.T) {
+	var meta Meta
+	require.NoError(t, json.Unmarshal([]byte(`{"timestamp": "2025-06-01T12:00:00Z", "request_id": "req-1", "region": "eu", "shard": 4}`), &meta))
+	assert.Equal(t, "req-1", meta.RequestID)
+	assert.Equal(t, map[string]json.RawMessage{
+		"region": json.RawMessage(`"eu"`),
+		"shard":  json.RawMessage(`4`),
+	}, meta.Extra)
+
+	meta.Extra["api_version"] = json.RawMessage(`"clobbered"`)
+	meta.APIVersion = "v2"
+	encoded, err := json.Marshal(meta)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"timestamp": "2025-06-01T12:00:00Z", "request_id": "req-1", "api_version": "v2", "region": "eu", "shard": 4}`, string(encoded))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"request_id": "req-2"}`), &meta))
+	assert.Nil(t, meta.Extra)
+}
+
+func TestResponseErrorCauseChain(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": false, "error": {
+		"code": "ORDER_FAILED", "message": "could not place order",
+		"cause": {"code": "PAYMENT_DECLINED", "message": "card declined",
+			"cause": {"code": "GATEWAY_TIMEOUT", "message": "no response from bank"}}
+	}}`))
+	require.NoError(t, err)
+
+	top := handler.GetError()
+	require.NotNil(t, top)
+	assert.Nil(t, top.Extra)
+
+	first := errors.Unwrap(top)
+	require.NotNil(t, first)
+	assert.Equal(t, "PAYMENT_DECLINED | card declined", first.Error())
+
+	second := errors.Unwrap(first)
+	require.NotNil(t, second)
+	assert.Equal(t, "GATEWAY_TIMEOUT | no response from bank", second.Error())
+	assert.Nil(t, errors.Unwrap(second))
+
+	var target *ResponseError
+	require.True(t, errors.As(first, &target))
+	assert.Equal(t, "PAYMENT_DECLINED", target.Code)
+	assert.True(t, errors.Is(top, second))
+
+	assert.Equal(t,
+		"ORDER_FAILED | could not place order | caused by: PAYMENT_DECLINED | card declined | caused by: GATEWAY_TIMEOUT | no response from bank",
+		handler.ErrorStringWithCauses())
+	assert.Equal(t, "ORDER_FAILED | could not place order", handler.ErrorString())
+}
+
+func TestResponseErrorCauseRoundTrip(t *testing.T) {
+	respErr := &ResponseError{
+		Code:    "OUTER",
+		Message: "outer",
+		Cause:   &ResponseError{Code: "INNER", Message: "inner"},
+	}
+
+	encoded, err := json.Marshal(respErr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code": "OUTER", "message": "outer", "cause": {"code": "INNER", "message": "inner"}}`, string(encoded))
+
+	var decoded ResponseError
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.NotNil(t, decoded.Cause)
+	assert.Equal(t, "INNER", decoded.Cause.Code)
+	assert.Nil(t, errors.Unwrap(decoded.Cause))
+
+	var nilHandler *Handler
+	assert.Empty(t, nilHandler.ErrorStringWithCauses())
+}
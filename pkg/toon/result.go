@@ -0,0 +1,43 @@
+package toon
+
+// Result captures the outcome of parsing a response for declarative handling
+// Exactly one of the OnSuccess, OnError and OnParseError callbacks fires
+type Result struct {
+	handler *Handler
+	err     error
+}
+
+// Parse parses body into a Result
+func Parse(body []byte) Result {
+	handler, err := NewHandler(body)
+	return Result{handler: handler, err: err}
+}
+
+// OnSuccess calls fn with the handler if the response indicates success
+func (r Result) OnSuccess(fn func(*Handler)) Result {
+	if r.err == nil && r.handler.IsSuccess() && fn != nil {
+		fn(r.handler)
+	}
+	return r
+}
+
+// OnError calls fn with the response error if the response indicates an error
+func (r Result) OnError(fn func(*ResponseError)) Result {
+	if r.err == nil && !r.handler.IsSuccess() && fn != nil {
+		fn(r.handler.GetError())
+	}
+	return r
+}
+
+// OnParseError calls fn with the parse error if the body could not be parsed
+func (r Result) OnParseError(fn func(error)) Result {
+	if r.err != nil && fn != nil {
+		fn(r.err)
+	}
+	return r
+}
+
+// Unwrap returns the handler and the parse error
+func (r Result) Unwrap() (*Handler, error) {
+	return r.handler, r.err
+}
@@ -0,0 +1,57 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCallbacks(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		expect string
+	}{
+		{name: "success", body: `{"success": true, "data": {"id": 1}}`, expect: "success"},
+		{name: "server error", body: `{"success": false, "error": {"code": "ERR", "message": "msg"}}`, expect: "error"},
+		{name: "parse error", body: `{invalid json}`, expect: "parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fired []string
+
+			Parse([]byte(tt.body)).
+				OnSuccess(func(h *Handler) {
+					fired = append(fired, "success")
+					assert.True(t, h.IsSuccess())
+				}).
+				OnError(func(e *ResponseError) {
+					fired = append(fired, "error")
+					require.NotNil(t, e)
+					assert.Equal(t, "ERR", e.Code)
+				}).
+				OnParseError(func(err error) {
+					fired = append(fired, "parse")
+					var valErr *ValidationError
+					assert.ErrorAs(t, err, &valErr)
+				})
+
+			assert.Equal(t, []string{tt.expect}, fired)
+		})
+	}
+}
+
+func TestResultUnwrap(t *testing.T) {
+	handler, err := Parse([]byte(`{"success": true}`)).Unwrap()
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+
+	handler, err = Parse(nil).Unwrap()
+	assert.Nil(t, handler)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeEmptyResponse, valErr.Code)
+}
@@ -0,0 +1,74 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// WithRootPath parses the envelope from a nested object instead of the top
+// level, for APIs that wrap it like {"response": {"success": true, ...}}
+// Nested wrappers are separated by dots, e.g. WithRootPath("result.response")
+// The handler is built from the nested object alone, so RawBody returns it
+// rather than the full body
+func WithRootPath(path string) Option {
+	return func(o *handlerOptions) {
+		o.rootPath = path
+	}
+}
+
+// navigateRootPath returns the object at the dotted path within body
+// An empty body is returned unchanged so NewHandler reports it as such
+func navigateRootPath(body []byte, path string) ([]byte, error) {
+	current := stripBOM(body)
+	if len(bytes.TrimSpace(current)) == 0 {
+		return body, nil
+	}
+
+	resolved := ""
+	for _, key := range strings.Split(path, ".") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(current, &fields); err != nil || fields == nil {
+			if err != nil && resolved == "" {
+				return nil, &ValidationError{
+					Code:    ErrCodeJSONUnmarshal,
+					Message: "failed to unmarshal response body",
+					Err:     err,
+					Context: map[string]interface{}{
+						"body_size": len(body),
+					},
+				}
+			}
+			return nil, rootPathNotFound(path, resolved, "root path does not lead to an object")
+		}
+
+		if resolved != "" {
+			resolved += "."
+		}
+		resolved += key
+
+		next, ok := fields[key]
+		if !ok {
+			return nil, rootPathNotFound(path, resolved, "root path not found in response body")
+		}
+		current = next
+	}
+
+	if trimmed := bytes.TrimSpace(current); len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, rootPathNotFound(path, resolved, "root path does not lead to an object")
+	}
+	return current, nil
+}
+
+// rootPathNotFound builds the error for a root path that cannot be followed
+// failedAt is the prefix of path resolved when the lookup failed
+func rootPathNotFound(path, failedAt, message string) *ValidationError {
+	return &ValidationError{
+		Code:    ErrCodeInvalidResponse,
+		Message: message,
+		Context: map[string]interface{}{
+			"root_path": path,
+			"failed_at": failedAt,
+		},
+	}
+}
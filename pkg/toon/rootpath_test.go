@@ -0,0 +1,69 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRootPathSingle(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{
+		"response": {"success": true, "data": {"id": 1}, "meta": {"request_id": "req-1"}},
+		"server": "edge-1"
+	}`), WithRootPath("response"))
+	require.NoError(t, err)
+
+	assert.True(t, handler.IsSuccess())
+	assert.JSONEq(t, `{"id": 1}`, string(handler.GetData()))
+	assert.Equal(t, "req-1", handler.GetRequestID())
+	assert.JSONEq(t, `{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-1"}}`, string(handler.RawBody()))
+}
+
+func TestWithRootPathNested(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{
+		"result": {"response": {"success": false, "error": {"code": "NOT_FOUND", "message": "missing"}}}
+	}`), WithRootPath("result.response"))
+	require.NoError(t, err)
+
+	assert.False(t, handler.IsSuccess())
+	assert.Equal(t, "NOT_FOUND", handler.GetError().Code)
+}
+
+func TestWithRootPathCombinesWithSuccessField(t *testing.T) {
+	handler, err := NewHandlerWithOptions([]byte(`{"response": {"ok": true, "data": [1]}}`),
+		WithRootPath("response"), WithSuccessField("ok"))
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+}
+
+func TestWithRootPathErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		path     string
+		code     ErrCode
+		failedAt string
+	}{
+		{"missing key", `{"response": {"success": true}}`, "result", ErrCodeInvalidResponse, "result"},
+		{"missing nested key", `{"result": {"other": {}}}`, "result.response", ErrCodeInvalidResponse, "result.response"},
+		{"not an object", `{"result": {"response": [1]}}`, "result.response", ErrCodeInvalidResponse, "result.response"},
+		{"scalar on the way", `{"result": 5}`, "result.response", ErrCodeInvalidResponse, "result"},
+		{"invalid json", `{bad`, "response", ErrCodeJSONUnmarshal, ""},
+		{"empty body", ``, "response", ErrCodeEmptyResponse, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHandlerWithOptions([]byte(tt.body), WithRootPath(tt.path))
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, tt.code, valErr.Code)
+			if tt.code == ErrCodeInvalidResponse {
+				assert.Equal(t, tt.failedAt, valErr.Context["failed_at"])
+				assert.Equal(t, tt.path, valErr.Context["root_path"])
+			}
+		})
+	}
+}
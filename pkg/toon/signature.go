@@ -0,0 +1,35 @@
+package toon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign returns a hex encoded HMAC-SHA256 of the raw response body under key
+// Stored alongside the body, it lets a cache later detect tampering with
+// VerifySignature
+// Returns empty string if the handler is nil
+func (h *Handler) Sign(key []byte) string {
+	if h == nil {
+		return ""
+	}
+	return hex.EncodeToString(bodyMAC(h.RawBody(), key))
+}
+
+// VerifySignature reports whether signature is the Sign result for body under key
+// The comparison is constant-time
+func VerifySignature(body []byte, key []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, bodyMAC(body, key))
+}
+
+// bodyMAC computes the HMAC-SHA256 of body under key
+func bodyMAC(body []byte, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
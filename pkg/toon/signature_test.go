@@ -0,0 +1,42 @@
+package toon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"id": 1, "amount": 100}}`)
+	key := []byte("cache-secret")
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+
+	signature := handler.Sign(key)
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, handler.Sign(key))
+	assert.True(t, VerifySignature(body, key, signature))
+}
+
+func TestVerifySignatureTampered(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"id": 1, "amount": 100}}`)
+	key := []byte("cache-secret")
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	signature := handler.Sign(key)
+
+	tampered := bytes.Replace(body, []byte("100"), []byte("900"), 1)
+	assert.False(t, VerifySignature(tampered, key, signature))
+	assert.False(t, VerifySignature(body, []byte("other-secret"), signature))
+	assert.False(t, VerifySignature(body, key, "not-hex"))
+	assert.False(t, VerifySignature(body, key, ""))
+}
+
+func TestSignNilHandler(t *testing.T) {
+	var handler *Handler
+	assert.Equal(t, "", handler.Sign([]byte("key")))
+}
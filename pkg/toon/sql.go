@@ -0,0 +1,79 @@
+package toon
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, storing the raw response body
+// This allows a Handler to be written to a JSON or JSONB column
+func (h *Handler) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	body := h.RawBody()
+	if body == nil {
+		return nil, nil
+	}
+	return body, nil
+}
+
+// Scan implements sql.Scanner, parsing a response body read from a database row
+// A NULL column resets the handler to an empty state
+// Scan replaces all of the handler's state, including local meta, and must
+// not race with other method calls
+func (h *Handler) Scan(src interface{}) error {
+	if h == nil {
+		return &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	var body []byte
+	switch v := src.(type) {
+	case nil:
+		h.replaceWith(&Handler{})
+		return nil
+	case []byte:
+		body = make([]byte, len(v))
+		copy(body, v)
+	case string:
+		body = []byte(v)
+	default:
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "unsupported scan source type",
+			Context: map[string]interface{}{
+				"source": fmt.Sprintf("%T", src),
+			},
+		}
+	}
+
+	parsed, err := NewHandler(body)
+	if err != nil {
+		return err
+	}
+	h.replaceWith(parsed)
+	return nil
+}
+
+// replaceWith replaces the state of h with that of n
+// Everything tied to the previous response is dropped, including local meta
+// and cached decodes, so a reused handler never mixes two responses
+func (h *Handler) replaceWith(n *Handler) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.resp = n.resp
+	h.body = n.body
+	h.rawErr = n.rawErr
+	h.rawError = n.rawError
+	h.opts = n.opts
+	h.responseState = n.responseState
+	h.localMeta = nil
+	h.dataCache = nil
+}
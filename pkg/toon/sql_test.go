@@ -0,0 +1,75 @@
+package toon
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ driver.Valuer = (*Handler)(nil)
+	_ sql.Scanner   = (*Handler)(nil)
+)
+
+func TestScanFromBytes(t *testing.T) {
+	var handler Handler
+	require.NoError(t, handler.Scan([]byte(`{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-db"}}`)))
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "req-db", handler.GetRequestID())
+}
+
+func TestScanFromString(t *testing.T) {
+	var handler Handler
+	require.NoError(t, handler.Scan(`{"success": false, "error": {"code": "ERR", "message": "msg"}}`))
+	assert.True(t, handler.IsError())
+	assert.Equal(t, "ERR | msg", handler.ErrorString())
+}
+
+func TestScanReplacesExistingState(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"request_id": "old"}}`))
+	require.NoError(t, err)
+
+	handler.SetLocalMeta("row", 1)
+
+	require.NoError(t, handler.Scan([]byte(`{"success": true, "meta": {"request_id": "new"}}`)))
+	assert.Equal(t, "new", handler.GetRequestID())
+
+	_, ok := handler.GetLocalMeta("row")
+	assert.False(t, ok)
+}
+
+func TestScanNilAndInvalid(t *testing.T) {
+	handler := MustNewHandler([]byte(`{"success": true}`))
+	require.NoError(t, handler.Scan(nil))
+	assert.False(t, handler.IsSuccess())
+	assert.Nil(t, handler.RawBody())
+
+	var valErr *ValidationError
+	require.ErrorAs(t, handler.Scan(42), &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+
+	require.ErrorAs(t, handler.Scan([]byte(`{invalid`)), &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestValueRoundTrip(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"id": 1}, "meta": {"request_id": "req-1"}}`)
+	original := MustNewHandler(body)
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.Equal(t, body, value)
+
+	var restored Handler
+	require.NoError(t, restored.Scan(value))
+	assert.Equal(t, original.GetRequestID(), restored.GetRequestID())
+	assert.Equal(t, original.GetData(), restored.GetData())
+
+	var empty *Handler
+	value, err = empty.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
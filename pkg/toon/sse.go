@@ -0,0 +1,64 @@
+package toon
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// SSEHandler reads Toon responses pushed as server-sent events
+// The data field of each event is parsed as a Toon response
+// SSEHandler is not safe for concurrent use
+type SSEHandler struct {
+	r *bufio.Reader
+}
+
+// NewSSEHandler creates an SSEHandler reading an event stream from r
+func NewSSEHandler(r io.Reader) *SSEHandler {
+	return &SSEHandler{r: bufio.NewReader(r)}
+}
+
+// Next returns the handler for the next event carrying data
+// Multi-line data fields are joined with newlines, comment lines starting
+// with ":" and other fields are skipped, and events end at a blank line
+// Returns io.EOF at the end of the stream
+func (s *SSEHandler) Next() (*Handler, error) {
+	var data []string
+	hasData := false
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, &ValidationError{
+				Code:    ErrCodeIORead,
+				Message: "failed to read event stream",
+				Err:     err,
+			}
+		}
+		atEOF := errors.Is(err, io.EOF)
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if hasData {
+				return NewHandler([]byte(strings.Join(data, "\n")))
+			}
+		case strings.HasPrefix(line, ":"):
+			// Comment line
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			if field == "data" {
+				data = append(data, strings.TrimPrefix(value, " "))
+				hasData = true
+			}
+		}
+
+		if atEOF {
+			if hasData {
+				return NewHandler([]byte(strings.Join(data, "\n")))
+			}
+			return nil, io.EOF
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package toon
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEHandler(t *testing.T) {
+	stream := ": keep-alive\n" +
+		"event: update\n" +
+		"id: 1\n" +
+		"data: {\"success\": true,\n" +
+		"data:  \"data\": {\"id\": 1}}\n" +
+		"\n" +
+		": another comment\n" +
+		"\n" +
+		"event: failure\r\n" +
+		"data: {\"success\": false, \"error\": {\"code\": \"ERR\", \"message\": \"msg\"}}\r\n" +
+		"\r\n"
+
+	sse := NewSSEHandler(strings.NewReader(stream))
+
+	first, err := sse.Next()
+	require.NoError(t, err)
+	assert.True(t, first.IsSuccess())
+	assert.JSONEq(t, `{"id": 1}`, string(first.GetData()))
+
+	second, err := sse.Next()
+	require.NoError(t, err)
+	assert.True(t, second.IsError())
+	assert.Equal(t, "ERR | msg", second.ErrorString())
+
+	_, err = sse.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestSSEHandlerFinalEventWithoutBlankLine(t *testing.T) {
+	sse := NewSSEHandler(strings.NewReader("data: {\"success\": true}"))
+
+	handler, err := sse.Next()
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+
+	_, err = sse.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestSSEHandlerInvalidEvent(t *testing.T) {
+	sse := NewSSEHandler(strings.NewReader("data: not json\n\n"))
+
+	_, err := sse.Next()
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
@@ -0,0 +1,135 @@
+package toon
+
+import (
+	"io"
+	"net/http"
+)
+
+// ErrorCodeStatus maps ResponseError codes to HTTP status codes
+// It is used by HTTPStatus and may be extended or overridden by callers;
+// modify it only during initialization as it is not safe for concurrent writes
+var ErrorCodeStatus = map[string]int{
+	"BAD_REQUEST":         http.StatusBadRequest,
+	"INVALID_INPUT":       http.StatusBadRequest,
+	"UNAUTHORIZED":        http.StatusUnauthorized,
+	"FORBIDDEN":           http.StatusForbidden,
+	"NOT_FOUND":           http.StatusNotFound,
+	"CONFLICT":            http.StatusConflict,
+	"VALIDATION":          http.StatusUnprocessableEntity,
+	"VALIDATION_ERROR":    http.StatusUnprocessableEntity,
+	"RATE_LIMITED":        http.StatusTooManyRequests,
+	"INTERNAL":            http.StatusInternalServerError,
+	"INTERNAL_ERROR":      http.StatusInternalServerError,
+	"NOT_IMPLEMENTED":     http.StatusNotImplemented,
+	"BAD_GATEWAY":         http.StatusBadGateway,
+	"SERVICE_UNAVAILABLE": http.StatusServiceUnavailable,
+	"TIMEOUT":             http.StatusGatewayTimeout,
+}
+
+// HTTPStatus returns the HTTP status code that best represents the response
+// Success responses map to 200, error codes are looked up in ErrorCodeStatus,
+// unknown error codes map to 400 and malformed responses map to 500
+// Handlers from FromHTTPResponseBare return the status they were received with
+func (h *Handler) HTTPStatus() int {
+	if h == nil || h.Response() == nil {
+		return http.StatusInternalServerError
+	}
+
+	h.mu.RLock()
+	bare, statusCode := h.bare, h.statusCode
+	h.mu.RUnlock()
+	if bare && statusCode != 0 {
+		return statusCode
+	}
+
+	if h.IsSuccess() {
+		return http.StatusOK
+	}
+
+	respErr := h.GetError()
+	if respErr == nil {
+		return http.StatusInternalServerError
+	}
+
+	if status, ok := ErrorCodeStatus[respErr.Code]; ok {
+		return status
+	}
+	return http.StatusBadRequest
+}
+
+// WriteTo writes the raw response body to w
+// When w is an http.ResponseWriter, the JSON content type and the status
+// from HTTPStatus are written first, forwarding the response in one call
+func (h *Handler) WriteTo(w io.Writer) (int64, error) {
+	if h == nil {
+		return 0, &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	if rw, ok := w.(http.ResponseWriter); ok {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(h.HTTPStatus())
+	}
+
+	n, err := w.Write(h.RawBody())
+	return int64(n), err
+}
+
+// IsServerFault checks if the response is an error that maps to a 5xx status
+// Circuit breakers typically only trip on server faults
+func (h *Handler) IsServerFault() bool {
+	if h.IsSuccess() {
+		return false
+	}
+	status := h.HTTPStatus()
+	return status >= 500 && status < 600
+}
+
+// IsClientFault checks if the response is an error that maps to a 4xx status
+func (h *Handler) IsClientFault() bool {
+	if h.IsSuccess() {
+		return false
+	}
+	status := h.HTTPStatus()
+	return status >= 400 && status < 500
+}
+
+// Outcome labels returned by Outcome
+const (
+	OutcomeSuccess     = "success"
+	OutcomeEmpty       = "empty"
+	OutcomeClientError = "client_error"
+	OutcomeServerError = "server_error"
+	OutcomeRateLimited = "rate_limited"
+)
+
+// Outcome returns a low-cardinality label for metrics, e.g. a Prometheus
+// counter vector: success, empty (a success without data, or no response),
+// rate_limited, server_error or client_error
+// A failure is rate_limited when IsRateLimited reports so or HTTPStatus is
+// 429; otherwise HTTPStatus decides between server and client errors
+// A success is never rate_limited, even if it used up the last of the quota
+func (h *Handler) Outcome() string {
+	if h == nil || h.Response() == nil {
+		return OutcomeEmpty
+	}
+
+	if h.IsSuccess() {
+		if h.DataLen() == 0 {
+			return OutcomeEmpty
+		}
+		return OutcomeSuccess
+	}
+
+	status := h.HTTPStatus()
+	switch {
+	case status == http.StatusTooManyRequests || h.IsRateLimited():
+		return OutcomeRateLimited
+	case status >= 500:
+		return OutcomeServerError
+	default:
+		return OutcomeClientError
+	}
+}
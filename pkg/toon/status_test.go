@@ -0,0 +1,116 @@
+package toon
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "success", body: `{"success": true}`, status: http.StatusOK},
+		{name: "not found", body: `{"success": false, "error": {"code": "NOT_FOUND", "message": "m"}}`, status: http.StatusNotFound},
+		{name: "unauthorized", body: `{"success": false, "error": {"code": "UNAUTHORIZED", "message": "m"}}`, status: http.StatusUnauthorized},
+		{name: "rate limited", body: `{"success": false, "error": {"code": "RATE_LIMITED", "message": "m"}}`, status: http.StatusTooManyRequests},
+		{name: "validation", body: `{"success": false, "error": {"code": "VALIDATION", "message": "m"}}`, status: http.StatusUnprocessableEntity},
+		{name: "unknown code", body: `{"success": false, "error": {"code": "SOMETHING_ELSE", "message": "m"}}`, status: http.StatusBadRequest},
+		{name: "missing error object", body: `{"success": false}`, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, handler.HTTPStatus())
+		})
+	}
+}
+
+func TestHTTPStatusOverride(t *testing.T) {
+	ErrorCodeStatus["TEAPOT"] = http.StatusTeapot
+	defer delete(ErrorCodeStatus, "TEAPOT")
+
+	handler, err := NewHandler([]byte(`{"success": false, "error": {"code": "TEAPOT", "message": "m"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTeapot, handler.HTTPStatus())
+}
+
+func TestWriteTo(t *testing.T) {
+	body := `{"success": false, "error": {"code": "NOT_FOUND", "message": "user not found"}}`
+	handler, err := NewHandler([]byte(body))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	n, err := handler.WriteTo(rec)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(body)), n)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, body, rec.Body.String())
+
+	var buf bytes.Buffer
+	_, err = handler.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, body, buf.String())
+}
+
+func TestFaultClassification(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		client bool
+		server bool
+	}{
+		{name: "success", body: `{"success": true}`},
+		{name: "validation", body: `{"success": false, "error": {"code": "VALIDATION", "message": "m"}}`, client: true},
+		{name: "service unavailable", body: `{"success": false, "error": {"code": "SERVICE_UNAVAILABLE", "message": "m"}}`, server: true},
+		{name: "unknown code", body: `{"success": false, "error": {"code": "ODD", "message": "m"}}`, client: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.client, handler.IsClientFault())
+			assert.Equal(t, tt.server, handler.IsServerFault())
+		})
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		outcome string
+	}{
+		{"success", `{"success": true, "data": {"id": 1}}`, OutcomeSuccess},
+		{"success without data", `{"success": true}`, OutcomeEmpty},
+		{"success using last quota", `{"success": true, "data": [1], "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "2025-01-01T00:00:00Z"}}}`, OutcomeSuccess},
+		{"not found", `{"success": false, "error": {"code": "NOT_FOUND", "message": "m"}}`, OutcomeClientError},
+		{"unknown code", `{"success": false, "error": {"code": "WHATEVER", "message": "m"}}`, OutcomeClientError},
+		{"internal", `{"success": false, "error": {"code": "INTERNAL", "message": "m"}}`, OutcomeServerError},
+		{"no error object", `{"success": false}`, OutcomeServerError},
+		{"rate limited code", `{"success": false, "error": {"code": "RATE_LIMITED", "message": "m"}}`, OutcomeRateLimited},
+		{"exhausted quota", `{"success": false, "error": {"code": "FORBIDDEN", "message": "m"}, "meta": {"rate_limit": {"limit": 10, "remaining": 0, "reset": "2025-01-01T00:00:00Z"}}}`, OutcomeRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHandler([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.outcome, handler.Outcome())
+		})
+	}
+
+	var nilHandler *Handler
+	assert.Equal(t, OutcomeEmpty, nilHandler.Outcome())
+	assert.Equal(t, OutcomeEmpty, (&Handler{}).Outcome())
+}
@@ -0,0 +1,47 @@
+package toon
+
+import "encoding/json"
+
+// IsStringEncodedData reports whether the data is a JSON string whose content
+// is itself a JSON object or array, as produced by gateways that encode the
+// data field twice
+func (h *Handler) IsStringEncodedData() bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.resp == nil {
+		return false
+	}
+	_, ok := unwrapStringData(h.resp.Data)
+	return ok
+}
+
+// unwrapStringData returns the JSON held inside a string-encoded data value
+// Plain strings and strings holding scalars are left alone to avoid false
+// positives such as "data": "123" or "data": "true"
+func unwrapStringData(data json.RawMessage) (json.RawMessage, bool) {
+	if dataKindOf(data) != DataKindString {
+		return nil, false
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, false
+	}
+
+	inner := json.RawMessage(s)
+	switch dataKindOf(inner) {
+	case DataKindObject, DataKindArray:
+	default:
+		return nil, false
+	}
+
+	if !json.Valid(inner) {
+		return nil, false
+	}
+	return inner, true
+}
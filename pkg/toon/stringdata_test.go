@@ -0,0 +1,89 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnwrapStringDataObject(t *testing.T) {
+	body := []byte(`{"success": true, "data": "{\"id\": 5, \"name\": \"nested\"}"}`)
+
+	handler, err := NewHandlerWithOptions(body, WithUnwrapStringData())
+	require.NoError(t, err)
+	assert.True(t, handler.IsStringEncodedData())
+
+	var data struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	require.NoError(t, handler.UnmarshalData(&data))
+	assert.Equal(t, 5, data.ID)
+	assert.Equal(t, "nested", data.Name)
+}
+
+func TestUnwrapStringDataArray(t *testing.T) {
+	body := []byte(`{"success": true, "data": "[1, 2, 3]"}`)
+
+	handler, err := NewHandlerWithOptions(body, WithUnwrapStringData())
+	require.NoError(t, err)
+
+	var data []int
+	require.NoError(t, handler.UnmarshalData(&data))
+	assert.Equal(t, []int{1, 2, 3}, data)
+}
+
+func TestUnwrapStringDataDisabledByDefault(t *testing.T) {
+	body := []byte(`{"success": true, "data": "{\"id\": 5}"}`)
+
+	handler, err := NewHandler(body)
+	require.NoError(t, err)
+	assert.True(t, handler.IsStringEncodedData())
+
+	var data string
+	require.NoError(t, handler.UnmarshalData(&data))
+	assert.Equal(t, `{"id": 5}`, data)
+}
+
+func TestUnwrapStringDataIgnoresPlainStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"plain text", `"hello"`, "hello"},
+		{"scalar json", `"123"`, "123"},
+		{"invalid object", `"{not json"`, "{not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(`{"success": true, "data": ` + tt.data + `}`)
+
+			handler, err := NewHandlerWithOptions(body, WithUnwrapStringData())
+			require.NoError(t, err)
+			assert.False(t, handler.IsStringEncodedData())
+
+			var data string
+			require.NoError(t, handler.UnmarshalData(&data))
+			assert.Equal(t, tt.want, data)
+		})
+	}
+}
+
+func TestUnwrapStringDataBeforeTransforms(t *testing.T) {
+	body := []byte(`{"success": true, "data": "{\"result\": {\"id\": 9}}"}`)
+
+	handler, err := NewHandlerWithOptions(body,
+		WithDataTransform(unwrapKey("result")),
+		WithUnwrapStringData(),
+	)
+	require.NoError(t, err)
+
+	var data struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, handler.UnmarshalData(&data))
+	assert.Equal(t, 9, data.ID)
+}
@@ -0,0 +1,148 @@
+package toon
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitHistory is the number of observations a RateLimitTracker
+// keeps when created with a non-positive size
+const DefaultRateLimitHistory = 32
+
+// rateLimitObservation is a rate limit seen at a point in time
+type rateLimitObservation struct {
+	at        time.Time
+	remaining int
+	reset     time.Time
+}
+
+// RateLimitTracker records the rate limits of successive responses to
+// estimate how fast the quota is consumed
+// Only the single rate limit of each response is tracked; named buckets are
+// ignored. Observations are kept in the order the responses were parsed and
+// start over when the quota window changes, i.e. the reset time moves
+// The zero value is ready to use and keeps DefaultRateLimitHistory observations
+// RateLimitTracker is safe for concurrent use
+type RateLimitTracker struct {
+	mu           sync.Mutex
+	size         int
+	observations []rateLimitObservation
+}
+
+// NewRateLimitTracker creates a tracker keeping the last size observations
+func NewRateLimitTracker(size int) *RateLimitTracker {
+	if size <= 0 {
+		size = DefaultRateLimitHistory
+	}
+	return &RateLimitTracker{size: size}
+}
+
+// Record adds the rate limit of h, observed at the time h was parsed
+// Handlers without a rate limit are ignored, as are handlers parsed before
+// the latest observation that belong to an earlier quota window
+func (t *RateLimitTracker) Record(h *Handler) {
+	if h == nil {
+		return
+	}
+	rl := h.GetRateLimit()
+	if rl == nil {
+		return
+	}
+
+	h.mu.RLock()
+	at := h.parsedAt
+	h.mu.RUnlock()
+	if at.IsZero() {
+		at = Now()
+	}
+
+	obs := rateLimitObservation{at: at, remaining: rl.Remaining, reset: rl.Reset}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	size := t.size
+	if size <= 0 {
+		size = DefaultRateLimitHistory
+	}
+
+	if n := len(t.observations); n > 0 {
+		last := t.observations[n-1]
+		if !last.reset.Equal(obs.reset) {
+			if obs.at.Before(last.at) {
+				return
+			}
+			t.observations = t.observations[:0]
+		}
+	}
+
+	// Insert after any observation made at the same time or earlier
+	i := sort.Search(len(t.observations), func(i int) bool {
+		return t.observations[i].at.After(obs.at)
+	})
+	t.observations = append(t.observations, rateLimitObservation{})
+	copy(t.observations[i+1:], t.observations[i:])
+	t.observations[i] = obs
+
+	if len(t.observations) > size {
+		copy(t.observations, t.observations[1:])
+		t.observations = t.observations[:size]
+	}
+}
+
+// BurnRate returns the requests consumed per second across the recorded
+// observations of the current quota window
+// Returns 0 with fewer than two observations or when no time has passed
+func (t *RateLimitTracker) BurnRate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.burnRate()
+}
+
+// burnRate implements BurnRate; t.mu must be held
+func (t *RateLimitTracker) burnRate() float64 {
+	n := len(t.observations)
+	if n < 2 {
+		return 0
+	}
+
+	first, last := t.observations[0], t.observations[n-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(first.remaining-last.remaining) / elapsed
+}
+
+// PredictExhaustion returns when the quota runs out if requests continue
+// at the current BurnRate
+// Returns the time of the last observation if the quota is already
+// exhausted, and the zero time if nothing is being consumed or the quota
+// resets before it would run out
+func (t *RateLimitTracker) PredictExhaustion() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := len(t.observations)
+	if n == 0 {
+		return time.Time{}
+	}
+
+	last := t.observations[n-1]
+	if last.remaining <= 0 {
+		return last.at
+	}
+
+	rate := t.burnRate()
+	if rate <= 0 {
+		return time.Time{}
+	}
+
+	exhaustion := last.at.Add(time.Duration(float64(last.remaining) / rate * float64(time.Second)))
+	if !last.reset.IsZero() && !exhaustion.Before(last.reset) {
+		return time.Time{}
+	}
+	return exhaustion
+}
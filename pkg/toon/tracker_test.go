@@ -0,0 +1,153 @@
+package toon
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitedHandler parses a response with the given rate limit at time at
+func rateLimitedHandler(t *testing.T, at time.Time, remaining int, reset string) *Handler {
+	t.Helper()
+
+	fixedNow(t, at)
+	handler, err := NewHandler([]byte(fmt.Sprintf(
+		`{"success": true, "meta": {"rate_limit": {"limit": 100, "remaining": %d, "reset": %q}}}`,
+		remaining, reset)))
+	require.NoError(t, err)
+	return handler
+}
+
+func TestRateLimitTrackerPredictExhaustion(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	reset := "2025-01-01T11:00:00Z"
+	tracker := NewRateLimitTracker(0)
+
+	// 2 requests per second: 100, 90, 80, 70 at 5 second intervals
+	for i := 0; i < 4; i++ {
+		tracker.Record(rateLimitedHandler(t, start.Add(time.Duration(i)*5*time.Second), 100-10*i, reset))
+	}
+
+	assert.InDelta(t, 2.0, tracker.BurnRate(), 1e-9)
+	assert.Equal(t, start.Add(15*time.Second+35*time.Second), tracker.PredictExhaustion())
+}
+
+func TestRateLimitTrackerResetsBeforeExhaustion(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := NewRateLimitTracker(0)
+
+	tracker.Record(rateLimitedHandler(t, start, 100, "2025-01-01T10:00:30Z"))
+	tracker.Record(rateLimitedHandler(t, start.Add(10*time.Second), 99, "2025-01-01T10:00:30Z"))
+
+	assert.InDelta(t, 0.1, tracker.BurnRate(), 1e-9)
+	assert.True(t, tracker.PredictExhaustion().IsZero())
+}
+
+func TestRateLimitTrackerNewWindow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := NewRateLimitTracker(0)
+
+	tracker.Record(rateLimitedHandler(t, start, 10, "2025-01-01T10:01:00Z"))
+	tracker.Record(rateLimitedHandler(t, start.Add(10*time.Second), 2, "2025-01-01T10:01:00Z"))
+	assert.InDelta(t, 0.8, tracker.BurnRate(), 1e-9)
+
+	tracker.Record(rateLimitedHandler(t, start.Add(70*time.Second), 100, "2025-01-01T10:02:00Z"))
+	assert.Zero(t, tracker.BurnRate())
+	assert.True(t, tracker.PredictExhaustion().IsZero())
+}
+
+func TestRateLimitTrackerExhausted(t *testing.T) {
+	at := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := NewRateLimitTracker(0)
+
+	tracker.Record(rateLimitedHandler(t, at, 0, "2025-01-01T10:01:00Z"))
+	assert.Equal(t, at, tracker.PredictExhaustion())
+}
+
+func TestRateLimitTrackerKeepsLastObservations(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	reset := "2025-01-01T11:00:00Z"
+	tracker := NewRateLimitTracker(2)
+
+	// Fast at first, then 1 request per second over the last two observations
+	tracker.Record(rateLimitedHandler(t, start, 100, reset))
+	tracker.Record(rateLimitedHandler(t, start.Add(time.Second), 50, reset))
+	tracker.Record(rateLimitedHandler(t, start.Add(11*time.Second), 40, reset))
+
+	assert.InDelta(t, 1.0, tracker.BurnRate(), 1e-9)
+}
+
+func TestRateLimitTrackerZeroValue(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	reset := "2025-01-01T11:00:00Z"
+
+	var tracker RateLimitTracker
+	tracker.Record(rateLimitedHandler(t, start, 100, reset))
+	tracker.Record(rateLimitedHandler(t, start.Add(10*time.Second), 90, reset))
+
+	assert.InDelta(t, 1.0, tracker.BurnRate(), 1e-9)
+}
+
+func TestRateLimitTrackerOutOfOrder(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	reset := "2025-01-01T11:00:00Z"
+	tracker := NewRateLimitTracker(0)
+
+	first := rateLimitedHandler(t, start, 100, reset)
+	second := rateLimitedHandler(t, start.Add(10*time.Second), 90, reset)
+	third := rateLimitedHandler(t, start.Add(20*time.Second), 80, reset)
+
+	// A response recorded late, with a higher remaining, keeps the window
+	tracker.Record(first)
+	tracker.Record(third)
+	tracker.Record(second)
+
+	assert.InDelta(t, 1.0, tracker.BurnRate(), 1e-9)
+	assert.Equal(t, start.Add(100*time.Second), tracker.PredictExhaustion())
+}
+
+func TestRateLimitTrackerIgnoresStaleWindow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tracker := NewRateLimitTracker(0)
+
+	stale := rateLimitedHandler(t, start, 5, "2025-01-01T10:00:30Z")
+	tracker.Record(rateLimitedHandler(t, start.Add(40*time.Second), 100, "2025-01-01T10:01:30Z"))
+	tracker.Record(rateLimitedHandler(t, start.Add(50*time.Second), 90, "2025-01-01T10:01:30Z"))
+	tracker.Record(stale)
+
+	assert.InDelta(t, 1.0, tracker.BurnRate(), 1e-9)
+}
+
+func TestRateLimitTrackerIgnoresMissingRateLimits(t *testing.T) {
+	tracker := NewRateLimitTracker(0)
+
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+	tracker.Record(handler)
+	tracker.Record(nil)
+
+	assert.Zero(t, tracker.BurnRate())
+	assert.True(t, tracker.PredictExhaustion().IsZero())
+}
+
+func TestRateLimitTrackerConcurrent(t *testing.T) {
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"rate_limit": {"limit": 10, "remaining": 5, "reset": "2025-01-01T10:00:00Z"}}}`))
+	require.NoError(t, err)
+
+	tracker := NewRateLimitTracker(4)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracker.Record(handler)
+			_ = tracker.BurnRate()
+			_ = tracker.PredictExhaustion()
+		}()
+	}
+	wg.Wait()
+}
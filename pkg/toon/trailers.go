@@ -0,0 +1,123 @@
+package toon
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trailer names read by FromHTTPResponse when the JSON meta is absent
+var (
+	trailerRequestID  = []string{DefaultRequestIDHeader, "Request-ID"}
+	trailerAPIVersion = []string{"X-API-Version", "API-Version"}
+	trailerLimit      = []string{"X-RateLimit-Limit", "RateLimit-Limit"}
+	trailerRemaining  = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+)
+
+// Reset trailers: the legacy header holds a Unix epoch or a timestamp, while
+// the IETF RateLimit header holds delta-seconds
+const (
+	trailerResetEpoch = "X-RateLimit-Reset"
+	trailerResetDelta = "RateLimit-Reset"
+)
+
+// captureTrailers fills Meta from HTTP trailers when the body carried no meta
+// Trailers are only populated by net/http after the body has been read to EOF,
+// so this must run after the body is fully consumed
+func (h *Handler) captureTrailers(trailer http.Header) {
+	if h == nil || len(trailer) == 0 {
+		return
+	}
+
+	meta := metaFromTrailer(trailer)
+	if meta == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.resp == nil || h.resp.Meta != nil {
+		return
+	}
+	h.resp.Meta = meta
+}
+
+// metaFromTrailer builds Meta from trailer values
+// Returns nil if none of the known trailers are present
+func metaFromTrailer(trailer http.Header) *Meta {
+	var meta Meta
+	found := false
+
+	if v := firstValue(trailer, trailerRequestID); v != "" {
+		meta.RequestID = v
+		found = true
+	}
+	if v := firstValue(trailer, trailerAPIVersion); v != "" {
+		meta.APIVersion = v
+		found = true
+	}
+
+	limit, okLimit := atoiValue(trailer, trailerLimit)
+	remaining, okRemaining := atoiValue(trailer, trailerRemaining)
+	if okLimit || okRemaining {
+		rl := &RateLimit{Limit: limit, Remaining: remaining}
+		if reset, ok := resetFromTrailer(trailer); ok {
+			rl.Reset = reset
+		}
+		meta.RateLimit = rl
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return &meta
+}
+
+// resetFromTrailer parses the rate limit reset time from the reset trailers
+func resetFromTrailer(trailer http.Header) (time.Time, bool) {
+	if v := strings.TrimSpace(trailer.Get(trailerResetEpoch)); v != "" {
+		raw := json.RawMessage(v)
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			raw = json.RawMessage(strconv.Quote(v))
+		}
+		reset, err := parseReset(raw)
+		return reset, err == nil
+	}
+
+	if v := strings.TrimSpace(trailer.Get(trailerResetDelta)); v != "" {
+		delta, err := strconv.Atoi(v)
+		if err != nil || delta < 0 {
+			return time.Time{}, false
+		}
+		return Now().Add(time.Duration(delta) * time.Second).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// firstValue returns the first non-empty value among the named headers
+func firstValue(header http.Header, names []string) string {
+	for _, name := range names {
+		if v := strings.TrimSpace(header.Get(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// atoiValue parses the first non-empty value among the named headers as an int
+func atoiValue(header http.Header, names []string) (int, bool) {
+	v := firstValue(header, names)
+	if v == "" {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
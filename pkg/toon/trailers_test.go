@@ -0,0 +1,109 @@
+package toon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveWithTrailers serves body and then sends the given trailers
+func serveWithTrailers(t *testing.T, body string, trailers map[string]string) *http.Response {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name := range trailers {
+			w.Header().Add("Trailer", name)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+		for name, value := range trailers {
+			w.Header().Set(name, value)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestFromHTTPResponseMergesTrailers(t *testing.T) {
+	resp := serveWithTrailers(t, `{"success": true, "data": {"id": 1}}`, map[string]string{
+		"X-Request-ID":          "req-trailer",
+		"X-RateLimit-Limit":     "100",
+		"X-RateLimit-Remaining": "42",
+		"X-RateLimit-Reset":     "1767225599",
+	})
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, "req-trailer", handler.GetRequestID())
+
+	rl := handler.GetRateLimit()
+	require.NotNil(t, rl)
+	assert.Equal(t, 100, rl.Limit)
+	assert.Equal(t, 42, rl.Remaining)
+	assert.Equal(t, time.Unix(1767225599, 0).UTC(), rl.Reset)
+}
+
+func TestFromHTTPResponseJSONMetaWinsOverTrailers(t *testing.T) {
+	resp := serveWithTrailers(t, `{"success": true, "meta": {"request_id": "req-body"}}`, map[string]string{
+		"X-Request-ID":      "req-trailer",
+		"X-RateLimit-Limit": "100",
+	})
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, "req-body", handler.GetRequestID())
+	assert.Nil(t, handler.GetRateLimit())
+}
+
+func TestFromHTTPResponseWithoutTrailers(t *testing.T) {
+	resp := serveWithTrailers(t, `{"success": true}`, nil)
+
+	handler, err := FromHTTPResponse(resp)
+	require.NoError(t, err)
+	assert.Nil(t, handler.GetMeta())
+}
+
+func TestMetaFromTrailerResetRFC3339(t *testing.T) {
+	trailer := http.Header{}
+	trailer.Set("RateLimit-Remaining", "0")
+	trailer.Set("X-RateLimit-Reset", "2025-12-31T23:59:59Z")
+	trailer.Set("API-Version", "v3")
+
+	meta := metaFromTrailer(trailer)
+	require.NotNil(t, meta)
+	assert.Equal(t, "v3", meta.APIVersion)
+	require.NotNil(t, meta.RateLimit)
+	assert.Equal(t, 0, meta.RateLimit.Remaining)
+	assert.Equal(t, time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC), meta.RateLimit.Reset)
+
+	assert.Nil(t, metaFromTrailer(http.Header{"Other": {"x"}}))
+}
+
+func TestMetaFromTrailerResetDeltaSeconds(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedNow(t, now)
+
+	trailer := http.Header{}
+	trailer.Set("RateLimit-Remaining", "0")
+	trailer.Set("RateLimit-Reset", "30")
+
+	meta := metaFromTrailer(trailer)
+	require.NotNil(t, meta)
+	require.NotNil(t, meta.RateLimit)
+	assert.Equal(t, now.Add(30*time.Second), meta.RateLimit.Reset)
+
+	trailer.Set("RateLimit-Reset", "-5")
+	meta = metaFromTrailer(trailer)
+	require.NotNil(t, meta)
+	assert.True(t, meta.RateLimit.Reset.IsZero())
+}
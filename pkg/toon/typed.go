@@ -0,0 +1,59 @@
+package toon
+
+// TypedHandler wraps a Handler together with its data decoded into T
+// It is safe for concurrent use; Data returns the value decoded at construction
+type TypedHandler[T any] struct {
+	handler *Handler
+	data    T
+}
+
+// NewTypedHandler creates a TypedHandler from raw bytes
+// On success responses with data, the data is decoded into T eagerly
+// Error responses leave the data as the zero value of T
+func NewTypedHandler[T any](body []byte) (*TypedHandler[T], error) {
+	handler, err := NewHandler(body)
+	if err != nil {
+		return nil, err
+	}
+
+	th := &TypedHandler[T]{handler: handler}
+	if handler.IsSuccess() && len(handler.GetData()) > 0 {
+		if err := handler.UnmarshalData(&th.data); err != nil {
+			return nil, err
+		}
+	}
+	return th, nil
+}
+
+// Data returns the decoded response data
+func (th *TypedHandler[T]) Data() T {
+	if th == nil {
+		var zero T
+		return zero
+	}
+	return th.data
+}
+
+// IsSuccess safely checks if the response indicates success
+func (th *TypedHandler[T]) IsSuccess() bool {
+	if th == nil {
+		return false
+	}
+	return th.handler.IsSuccess()
+}
+
+// Err returns the error from the response, if present
+func (th *TypedHandler[T]) Err() *ResponseError {
+	if th == nil {
+		return nil
+	}
+	return th.handler.GetError()
+}
+
+// Handler returns the underlying untyped Handler
+func (th *TypedHandler[T]) Handler() *Handler {
+	if th == nil {
+		return nil
+	}
+	return th.handler
+}
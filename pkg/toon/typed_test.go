@@ -0,0 +1,44 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestTypedHandlerSuccess(t *testing.T) {
+	body := []byte(`{"success": true, "data": {"id": 1, "name": "test"}, "meta": {"request_id": "req-123"}}`)
+
+	th, err := NewTypedHandler[typedUser](body)
+	require.NoError(t, err)
+	assert.True(t, th.IsSuccess())
+	assert.Nil(t, th.Err())
+	assert.Equal(t, typedUser{ID: 1, Name: "test"}, th.Data())
+	assert.Equal(t, "req-123", th.Handler().GetRequestID())
+}
+
+func TestTypedHandlerErrorPassthrough(t *testing.T) {
+	body := []byte(`{"success": false, "error": {"code": "NOT_FOUND", "message": "user not found"}}`)
+
+	th, err := NewTypedHandler[typedUser](body)
+	require.NoError(t, err)
+	assert.False(t, th.IsSuccess())
+	assert.Equal(t, typedUser{}, th.Data())
+	require.NotNil(t, th.Err())
+	assert.Equal(t, "NOT_FOUND", th.Err().Code)
+}
+
+func TestTypedHandlerDecodeError(t *testing.T) {
+	th, err := NewTypedHandler[typedUser]([]byte(`{"success": true, "data": [1, 2]}`))
+	assert.Nil(t, th)
+
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
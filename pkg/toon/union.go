@@ -0,0 +1,49 @@
+package toon
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeResult decodes an endpoint with distinct success and error payload shapes
+// On success the data is decoded into S; on failure the extra fields of the
+// error object (see ResponseError.Extra) are decoded into E
+// Exactly one of success and failure is non-nil when err is nil
+func DecodeResult[S any, E any](body []byte) (success *S, failure *E, err error) {
+	handler, err := NewHandler(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if handler.IsSuccess() {
+		success = new(S)
+		if handler.DataLen() > 0 {
+			if err := handler.UnmarshalData(success); err != nil {
+				return nil, nil, err
+			}
+		}
+		return success, nil, nil
+	}
+
+	failure = new(E)
+	respErr := handler.GetError()
+	if respErr == nil || len(respErr.Extra) == 0 {
+		return nil, failure, nil
+	}
+
+	extra, err := json.Marshal(respErr.Extra)
+	if err == nil {
+		err = json.Unmarshal(extra, failure)
+	}
+	if err != nil {
+		return nil, nil, &ValidationError{
+			Code:    ErrCodeJSONUnmarshal,
+			Message: "failed to unmarshal error extra into target type",
+			Err:     err,
+			Context: map[string]interface{}{
+				"target": fmt.Sprintf("%T", failure),
+			},
+		}
+	}
+	return nil, failure, nil
+}
@@ -0,0 +1,62 @@
+package toon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unionOrder struct {
+	ID    int `json:"id"`
+	Total int `json:"total"`
+}
+
+type unionDecline struct {
+	Reason     string `json:"reason"`
+	RetryAfter int    `json:"retry_after"`
+}
+
+func TestDecodeResultSuccess(t *testing.T) {
+	success, failure, err := DecodeResult[unionOrder, unionDecline]([]byte(`{"success": true, "data": {"id": 7, "total": 1200}}`))
+	require.NoError(t, err)
+	assert.Nil(t, failure)
+	require.NotNil(t, success)
+	assert.Equal(t, unionOrder{ID: 7, Total: 1200}, *success)
+}
+
+func TestDecodeResultFailure(t *testing.T) {
+	body := []byte(`{
+		"success": false,
+		"error": {"code": "DECLINED", "message": "card declined", "reason": "insufficient_funds", "retry_after": 30}
+	}`)
+
+	success, failure, err := DecodeResult[unionOrder, unionDecline](body)
+	require.NoError(t, err)
+	assert.Nil(t, success)
+	require.NotNil(t, failure)
+	assert.Equal(t, unionDecline{Reason: "insufficient_funds", RetryAfter: 30}, *failure)
+}
+
+func TestDecodeResultParseError(t *testing.T) {
+	success, failure, err := DecodeResult[unionOrder, unionDecline]([]byte(`{invalid json}`))
+	assert.Nil(t, success)
+	assert.Nil(t, failure)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeJSONUnmarshal, valErr.Code)
+}
+
+func TestResponseErrorExtraRoundTrip(t *testing.T) {
+	handler := MustNewHandler([]byte(`{"success": false, "error": {"code": "ERR", "message": "msg", "hint": "retry later"}}`))
+
+	respErr := handler.GetError()
+	require.NotNil(t, respErr)
+	assert.Equal(t, `"retry later"`, string(respErr.Extra["hint"]))
+
+	require.NoError(t, handler.SetRequestID("req-1"))
+	reparsed := MustNewHandler(handler.RawBody())
+	assert.Equal(t, `"retry later"`, string(reparsed.GetError().Extra["hint"]))
+	assert.Equal(t, "ERR | msg", reparsed.ErrorString())
+}
@@ -1,5 +1,58 @@
 package toon
 
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ResponseValidator checks an org-specific invariant on a parsed response
+// It receives a copy of the response that it may mutate freely
+type ResponseValidator func(*Response) error
+
+// namedValidator pairs a registered validator with its name
+type namedValidator struct {
+	name string
+	fn   ResponseValidator
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   []namedValidator
+)
+
+// RegisterValidator adds a validator run by ValidateAll on every handler
+// Registering a name again replaces the earlier validator in place
+// A nil fn removes the validator with that name
+func RegisterValidator(name string, fn ResponseValidator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	for i, v := range validators {
+		if v.name != name {
+			continue
+		}
+		if fn == nil {
+			validators = append(validators[:i:i], validators[i+1:]...)
+		} else {
+			validators[i].fn = fn
+		}
+		return
+	}
+
+	if fn != nil {
+		validators = append(validators, namedValidator{name: name, fn: fn})
+	}
+}
+
+// registeredValidators returns a snapshot of the registered validators
+func registeredValidators() []namedValidator {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+
+	return append([]namedValidator(nil), validators...)
+}
+
 // Validate performs comprehensive validation on the response
 // Returns ValidationError if validation fails
 func (h *Handler) Validate() error {
@@ -17,8 +70,9 @@ func (h *Handler) Validate() error {
 		}
 	}
 
-	// If response indicates error, ensure error object is present
-	if !h.resp.Success && h.resp.Error == nil {
+	// If response indicates error, ensure error object is present; a
+	// multi-status batch reports its failures in the sub-results instead
+	if !h.resp.Success && h.resp.Error == nil && len(h.resp.Results) == 0 {
 		return &ValidationError{
 			Code:    ErrCodeInvalidResponse,
 			Message: "success is false but error object is missing",
@@ -43,3 +97,78 @@ func (h *Handler) Validate() error {
 
 	return nil
 }
+
+// RequireRequestID returns a ValidationError if the response meta has no request ID
+// Strict environments can use it to catch upstreams that drop correlation IDs
+func (h *Handler) RequireRequestID() error {
+	if h == nil {
+		return &ValidationError{
+			Code:    ErrCodeNilHandler,
+			Message: "handler is nil",
+		}
+	}
+
+	if h.GetRequestID() == "" {
+		return &ValidationError{
+			Code:    ErrCodeMissingRequestID,
+			Message: "response meta has no request_id",
+		}
+	}
+	return nil
+}
+
+// ValidateAll runs the built-in Validate checks followed by every validator
+// registered with RegisterValidator, in registration order
+// All failures are joined with errors.Join; each validator failure is a
+// ValidationError with code VALIDATOR_FAILED naming the validator
+func (h *Handler) ValidateAll() error {
+	if err := h.Validate(); err != nil {
+		var valErr *ValidationError
+		if errors.As(err, &valErr) && (valErr.Code == ErrCodeNilHandler || valErr.Code == ErrCodeNilResponse) {
+			return err
+		}
+		return errors.Join(err, h.runValidators())
+	}
+	return h.runValidators()
+}
+
+// runValidators runs the registered validators and joins their failures
+// Each validator gets its own copy of the response, so a validator that
+// mutates it cannot affect the handler or the validators after it
+func (h *Handler) runValidators() error {
+	var errs []error
+	for _, v := range registeredValidators() {
+		if err := v.fn(h.ResponseCopy()); err != nil {
+			errs = append(errs, &ValidationError{
+				Code:    ErrCodeValidatorFailed,
+				Message: "validator " + v.name + " failed",
+				Err:     err,
+				Context: map[string]interface{}{
+					"validator": v.name,
+				},
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateAll runs Validate on every handler in a batch
+// Each failure is prefixed with the handler's index and its ValidationError
+// gains an "index" context key; failures are joined with errors.Join
+// Returns nil if every handler is valid
+func ValidateAll(handlers []*Handler) error {
+	var errs []error
+	for i, h := range handlers {
+		err := h.Validate()
+		if err == nil {
+			continue
+		}
+
+		var valErr *ValidationError
+		if errors.As(err, &valErr) {
+			err = withContext(valErr.clone(), map[string]interface{}{"index": i})
+		}
+		errs = append(errs, fmt.Errorf("handler %d: %w", i, err))
+	}
+	return errors.Join(errs...)
+}
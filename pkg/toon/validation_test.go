@@ -0,0 +1,140 @@
+package toon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerTestValidator registers a validator and removes it when the test ends
+func registerTestValidator(t *testing.T, name string, fn ResponseValidator) {
+	t.Helper()
+	RegisterValidator(name, fn)
+	t.Cleanup(func() { RegisterValidator(name, nil) })
+}
+
+func requireAPIVersion(resp *Response) error {
+	if resp.Meta == nil || resp.Meta.APIVersion == "" {
+		return errors.New("api_version must be present")
+	}
+	return nil
+}
+
+func TestValidateAllRunsRegisteredValidators(t *testing.T) {
+	registerTestValidator(t, "api_version", requireAPIVersion)
+
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"api_version": "v2"}}`))
+	require.NoError(t, err)
+	assert.NoError(t, handler.ValidateAll())
+
+	handler, err = NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	err = handler.ValidateAll()
+	require.Error(t, err)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeValidatorFailed, valErr.Code)
+	assert.Equal(t, "api_version", valErr.Context["validator"])
+	assert.Contains(t, err.Error(), "api_version must be present")
+}
+
+func TestValidateAllValidatorCannotMutateHandler(t *testing.T) {
+	registerTestValidator(t, "mutator", func(resp *Response) error {
+		resp.Success = false
+		resp.Meta.APIVersion = "tampered"
+		return nil
+	})
+
+	handler, err := NewHandler([]byte(`{"success": true, "meta": {"api_version": "v2"}}`))
+	require.NoError(t, err)
+	require.NoError(t, handler.ValidateAll())
+
+	assert.True(t, handler.IsSuccess())
+	assert.Equal(t, "v2", handler.GetAPIVersion())
+}
+
+func TestValidateAllJoinsFailures(t *testing.T) {
+	registerTestValidator(t, "api_version", requireAPIVersion)
+	registerTestValidator(t, "always_fails", func(*Response) error {
+		return errors.New("boom")
+	})
+
+	handler, err := NewHandler([]byte(`{"success": false}`))
+	require.NoError(t, err)
+
+	err = handler.ValidateAll()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "success is false but error object is missing")
+	assert.Contains(t, err.Error(), "api_version must be present")
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestRegisterValidatorReplaceAndRemove(t *testing.T) {
+	calls := 0
+	registerTestValidator(t, "counter", func(*Response) error {
+		calls++
+		return errors.New("first")
+	})
+	RegisterValidator("counter", func(*Response) error {
+		calls += 10
+		return nil
+	})
+
+	handler, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, handler.ValidateAll())
+	assert.Equal(t, 10, calls)
+
+	RegisterValidator("counter", nil)
+	assert.NoError(t, handler.ValidateAll())
+	assert.Equal(t, 10, calls)
+}
+
+func TestValidateAllNilHandler(t *testing.T) {
+	registerTestValidator(t, "api_version", requireAPIVersion)
+
+	var handler *Handler
+	err := handler.ValidateAll()
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+}
+
+func TestValidateAllBatch(t *testing.T) {
+	valid, err := NewHandler([]byte(`{"success": true, "data": {"id": 1}}`))
+	require.NoError(t, err)
+	invalid, err := NewHandler([]byte(`{"success": false}`))
+	require.NoError(t, err)
+
+	assert.NoError(t, ValidateAll([]*Handler{valid, valid}))
+	assert.NoError(t, ValidateAll(nil))
+
+	err = ValidateAll([]*Handler{valid, invalid, valid})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "handler 1:")
+	assert.NotContains(t, err.Error(), "handler 0:")
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+	assert.Equal(t, 1, valErr.Context["index"])
+}
+
+func TestValidateAllBatchNilHandler(t *testing.T) {
+	valid, err := NewHandler([]byte(`{"success": true}`))
+	require.NoError(t, err)
+
+	err = ValidateAll([]*Handler{valid, nil})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "handler 1:")
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeNilHandler, valErr.Code)
+}
@@ -0,0 +1,107 @@
+package toon
+
+import "io"
+
+// ResponseWriter streams a successful Toon response envelope to an io.Writer
+// so that large data payloads never have to be held in memory
+// Call WriteSuccessHeader, stream the data value through DataWriter, then Close
+type ResponseWriter struct {
+	w        io.Writer
+	state    int
+	dataSize int64
+	err      error
+}
+
+// ResponseWriter states
+const (
+	writerStateNew = iota
+	writerStateData
+	writerStateClosed
+)
+
+// NewResponseWriter creates a ResponseWriter that writes to w
+func NewResponseWriter(w io.Writer) *ResponseWriter {
+	return &ResponseWriter{w: w}
+}
+
+// WriteSuccessHeader writes the opening of a successful envelope up to the data value
+func (rw *ResponseWriter) WriteSuccessHeader() error {
+	if rw.err != nil {
+		return rw.err
+	}
+	if rw.state != writerStateNew {
+		return &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "response header already written",
+		}
+	}
+
+	rw.state = writerStateData
+	return rw.write([]byte(`{"success":true,"data":`))
+}
+
+// DataWriter returns a writer for the data value
+// The caller is responsible for writing exactly one valid JSON value;
+// writes fail until WriteSuccessHeader has been called and after Close
+func (rw *ResponseWriter) DataWriter() io.Writer {
+	return dataWriter{rw}
+}
+
+// Close finishes the envelope, writing the header first if needed
+// A data value of null is written when nothing was streamed
+func (rw *ResponseWriter) Close() error {
+	if rw.state == writerStateClosed {
+		return rw.err
+	}
+	if rw.state == writerStateNew {
+		if err := rw.WriteSuccessHeader(); err != nil {
+			return err
+		}
+	}
+
+	if rw.dataSize == 0 {
+		if err := rw.write([]byte("null")); err != nil {
+			return err
+		}
+	}
+
+	rw.state = writerStateClosed
+	return rw.write([]byte("}"))
+}
+
+// write writes p to the underlying writer, recording the first error
+func (rw *ResponseWriter) write(p []byte) error {
+	if rw.err != nil {
+		return rw.err
+	}
+	if _, err := rw.w.Write(p); err != nil {
+		rw.err = err
+	}
+	return rw.err
+}
+
+// dataWriter streams the data value of a ResponseWriter
+type dataWriter struct {
+	rw *ResponseWriter
+}
+
+// Write implements io.Writer
+func (d dataWriter) Write(p []byte) (int, error) {
+	rw := d.rw
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	if rw.state != writerStateData {
+		return 0, &ValidationError{
+			Code:    ErrCodeInvalidResponse,
+			Message: "data written outside the data section",
+		}
+	}
+
+	n, err := rw.w.Write(p)
+	rw.dataSize += int64(n)
+	if err != nil {
+		rw.err = err
+	}
+	return n, err
+}
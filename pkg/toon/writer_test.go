@@ -0,0 +1,85 @@
+package toon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseWriterStreamsLargeArray(t *testing.T) {
+	const count = 10000
+
+	var buf bytes.Buffer
+	rw := NewResponseWriter(&buf)
+	require.NoError(t, rw.WriteSuccessHeader())
+
+	data := rw.DataWriter()
+	_, err := data.Write([]byte("["))
+	require.NoError(t, err)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			_, err = data.Write([]byte(","))
+			require.NoError(t, err)
+		}
+		_, err = fmt.Fprintf(data, `{"id":%d}`, i)
+		require.NoError(t, err)
+	}
+	_, err = data.Write([]byte("]"))
+	require.NoError(t, err)
+	require.NoError(t, rw.Close())
+
+	handler, err := NewHandler(buf.Bytes())
+	require.NoError(t, err)
+	assert.True(t, handler.IsSuccess())
+
+	var items []struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, handler.UnmarshalData(&items))
+	require.Len(t, items, count)
+	assert.Equal(t, count-1, items[count-1].ID)
+}
+
+func TestResponseWriterCloseWithoutData(t *testing.T) {
+	var buf bytes.Buffer
+	rw := NewResponseWriter(&buf)
+	require.NoError(t, rw.Close())
+	require.NoError(t, rw.Close())
+
+	assert.True(t, json.Valid(buf.Bytes()))
+	assert.JSONEq(t, `{"success": true, "data": null}`, buf.String())
+}
+
+func TestResponseWriterMisuse(t *testing.T) {
+	var buf bytes.Buffer
+	rw := NewResponseWriter(&buf)
+
+	_, err := rw.DataWriter().Write([]byte("1"))
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, ErrCodeInvalidResponse, valErr.Code)
+
+	require.NoError(t, rw.WriteSuccessHeader())
+	require.ErrorAs(t, rw.WriteSuccessHeader(), &valErr)
+
+	require.NoError(t, rw.Close())
+	_, err = rw.DataWriter().Write([]byte("1"))
+	assert.Error(t, err)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestResponseWriterPropagatesWriteErrors(t *testing.T) {
+	rw := NewResponseWriter(failingWriter{})
+	assert.EqualError(t, rw.WriteSuccessHeader(), "disk full")
+	assert.EqualError(t, rw.Close(), "disk full")
+}